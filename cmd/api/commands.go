@@ -0,0 +1,573 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/fs"
+	"os"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+
+	"go.uber.org/zap"
+
+	"khalif-alquran/internal/config"
+	"khalif-alquran/internal/domain"
+	"khalif-alquran/internal/repository"
+	"khalif-alquran/pkg/database"
+	"khalif-alquran/pkg/logger"
+
+)
+
+const usage = `Usage: server [-refresh] [command]
+
+Without a command the HTTP and gRPC servers are started.
+
+Commands:
+  migrate up [N]     Apply all (or the next N) pending migrations
+  migrate down N     Roll back the last N applied migrations
+  migrate status     Show applied and pending migrations
+  migrate force V    Mark the schema as being at version V without running SQL
+  schema check [--json]
+                     Compare the live database with migrations and domain models
+  seed [--overwrite] [--json]
+                     Add missing Quran seed data and fill empty fields (safe to re-run);
+                     --overwrite also replaces changed rows and removes rows not in
+                     the seed files, reverting imported data to the seed corpus
+  seed validate [--dir path]
+                     Check the seed corpus integrity and print a JSON report
+                     (no database needed; defaults to SEED_DIR or the embedded corpus)
+  import tanzil --target arabic|translation [--edition CODE] [--script NAME] [--format text|xml] FILE
+                     Merge a Tanzil export (sura|aya|text or quran-*.xml) into ayahs;
+                     --edition writes a non-default translation (e.g. en.sahih) listed
+                     in translations.json, --script writes the imlaei or indopak text
+  import tafsir FILE Load one tafsir edition (tafsir/<code>.json format) and replace
+                     its entries
+  import morphology FILE
+                     Merge a Quranic Arabic Corpus morphology file
+                     (quranic-corpus-morphology-*.txt) into morphology_segments
+  import riwayah FILE
+                     Load one riwayah (riwayat/<code>.json format, warsh or qalun)
+                     and replace its ayah numbering and text
+  dataset export --out DIR [--version V]
+                     Write the database back to seed JSON files plus manifest.json
+`
+
+// runOfflineCommand menjalankan subcommand yang tidak membutuhkan koneksi database.
+// handled bernilai false jika args bukan perintah offline.
+func runOfflineCommand(args []string) (handled bool, err error) {
+	if len(args) >= 2 && args[0] == "seed" && args[1] == "validate" {
+		return true, runSeedValidate(args[2:])
+	}
+	return false, nil
+}
+
+// runCommand menjalankan subcommand CLI (misal: `server migrate status`) lalu keluar
+func runCommand(app *App, cfg *config.Config, args []string) error {
+	switch args[0] {
+	case "migrate":
+		return runMigrate(database.NewMigrator(app.DB), args[1:])
+	case "schema":
+		return runSchema(app, args[1:])
+	case "seed":
+		return runSeed(app, cfg, args[1:])
+	case "import":
+		return runImport(app, cfg, args[1:])
+	case "dataset":
+		return runDataset(app, database.SeedSource(cfg.SeedDir), args[1:])
+	case "help", "-h", "--help":
+		fmt.Print(usage)
+		return nil
+	default:
+		return fmt.Errorf("unknown command %q\n\n%s", args[0], usage)
+	}
+}
+
+func runMigrate(m *database.Migrator, args []string) error {
+	if len(args) == 0 {
+		return fmt.Errorf("missing migrate action\n\n%s", usage)
+	}
+
+	switch args[0] {
+	case "up":
+		n := 0
+		if len(args) > 1 {
+			parsed, err := parsePositiveArg(args[1], "N")
+			if err != nil {
+				return err
+			}
+			n = parsed
+		}
+
+		count, err := m.UpN(n)
+		fmt.Printf("Applied %d migration(s)\n", count)
+		return err
+
+	case "down":
+		if len(args) < 2 {
+			return fmt.Errorf("migrate down requires a step count, e.g. `migrate down 1`")
+		}
+		n, err := parsePositiveArg(args[1], "N")
+		if err != nil {
+			return err
+		}
+
+		count, err := m.Down(n)
+		fmt.Printf("Rolled back %d migration(s)\n", count)
+		return err
+
+	case "status":
+		statuses, err := m.Status()
+		if err != nil {
+			return err
+		}
+		printMigrationStatus(statuses)
+		return nil
+
+	case "force":
+		if len(args) < 2 {
+			return fmt.Errorf("migrate force requires a version, e.g. `migrate force 1`")
+		}
+		version, err := strconv.Atoi(args[1])
+		if err != nil || version < 0 {
+			return fmt.Errorf("invalid version %q", args[1])
+		}
+
+		if err := m.Force(version); err != nil {
+			return err
+		}
+		fmt.Printf("Schema version forced to %d\n", version)
+		return nil
+
+	default:
+		return fmt.Errorf("unknown migrate action %q\n\n%s", args[0], usage)
+	}
+}
+
+func runSchema(app *App, args []string) error {
+	if len(args) == 0 || args[0] != "check" {
+		return fmt.Errorf("unknown schema action\n\n%s", usage)
+	}
+
+	report, err := database.CheckSchemaDrift(app.DB)
+	if err != nil {
+		return err
+	}
+
+	if hasFlag(args[1:], "--json") {
+		if err := printJSON(report); err != nil {
+			return err
+		}
+	} else {
+		printDriftReport(report)
+	}
+
+	if report.HasErrors() {
+		return fmt.Errorf("schema drift detected")
+	}
+	return nil
+}
+
+// runSeed tanpa --overwrite hanya melengkapi data yang belum ada, sama seperti saat boot, sehingga
+// hasil `import tanzil`/`import tafsir` tidak dikembalikan ke isi corpus seed
+func runSeed(app *App, cfg *config.Config, args []string) error {
+	for _, arg := range args {
+		if arg != "--json" && arg != "--overwrite" {
+			return fmt.Errorf("unknown seed argument %q\n\n%s", arg, usage)
+		}
+	}
+
+	// Perintah CLI tidak menjalankan migrasi otomatis seperti saat boot
+	if err := requireMigrated(database.NewMigrator(app.DB)); err != nil {
+		return err
+	}
+
+	report, err := database.Seed(app.DB, database.SeedSource(cfg.SeedDir), cfg.AudioURLTemplate, hasFlag(args, "--overwrite"))
+	if err != nil {
+		return err
+	}
+
+	if hasFlag(args, "--json") {
+		return printJSON(report)
+	}
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "SURAH\tNAME\tMETADATA\tINSERTED\tUPDATED\tUNCHANGED")
+	for _, s := range report.Surahs {
+		fmt.Fprintf(w, "%d\t%s\t%s\t%d\t%d\t%d\n", s.Number, s.LatinName, s.Surah, s.Inserted, s.Updated, s.Unchanged)
+	}
+	fmt.Fprintf(w, "\tTOTAL\t\t%d\t%d\t%d\n", report.Inserted, report.Updated, report.Unchanged)
+	w.Flush()
+
+	if report.Renumbered > 0 || report.AudioFilled > 0 {
+		fmt.Printf("Renumbered %d ayah(s), filled %d audio URL(s)\n", report.Renumbered, report.AudioFilled)
+	}
+	if report.Mushaf > 0 {
+		fmt.Printf("Rewrote %d mushaf layout(s)\n", report.Mushaf)
+	}
+
+	// Cache detail surah harus dibuang agar koreksi teks langsung terlihat
+	if report.Changed() {
+		clearQuranCache(app)
+	}
+	return nil
+}
+
+func clearQuranCache(app *App) {
+	if app.RDB == nil {
+		return
+	}
+
+	ctx := context.Background()
+	cache := repository.NewRedisRepository(app.RDB)
+
+	err := cache.Del(ctx, domain.CacheKeySurahAll)
+	if err == nil {
+		err = cache.DeletePrefix(ctx, domain.CacheKeySurahPrefix)
+	}
+	if err == nil {
+		err = cache.DeletePrefix(ctx, domain.CacheKeyDivisionPrefix)
+	}
+	if err == nil {
+		err = cache.DeletePrefix(ctx, domain.CacheKeyMushafPrefix)
+	}
+	if err == nil {
+		err = cache.DeletePrefix(ctx, domain.CacheKeyTranslationPrefix)
+	}
+	if err == nil {
+		err = cache.DeletePrefix(ctx, domain.CacheKeyTafsirPrefix)
+	}
+	if err == nil {
+		err = cache.DeletePrefix(ctx, domain.CacheKeyWordPrefix)
+	}
+	if err == nil {
+		err = cache.DeletePrefix(ctx, domain.CacheKeyMorphologyPrefix)
+	}
+	if err == nil {
+		err = cache.DeletePrefix(ctx, domain.CacheKeyRiwayahPrefix)
+	}
+	if err == nil {
+		err = cache.DeletePrefix(ctx, domain.CacheKeyTajwidPrefix)
+	}
+	if err != nil {
+		logger.Error("Failed to clear Quran cache, stale data may be served until TTL expires", zap.Error(err))
+	}
+}
+
+func runSeedValidate(args []string) error {
+	dir := os.Getenv("SEED_DIR")
+	for i := 0; i < len(args); i++ {
+		if args[i] == "--dir" && i+1 < len(args) {
+			dir = args[i+1]
+			i++
+		}
+	}
+
+	report, err := database.ValidateSeed(database.SeedSource(dir))
+	if err != nil {
+		return err
+	}
+
+	if err := printJSON(report); err != nil {
+		return err
+	}
+
+	if !report.Valid {
+		return fmt.Errorf("seed validation failed with %d violation(s)", len(report.Violations))
+	}
+	return nil
+}
+
+func runImport(app *App, cfg *config.Config, args []string) error {
+	if len(args) > 0 && args[0] == "tafsir" {
+		return runImportTafsir(app, args[1:])
+	}
+	if len(args) > 0 && args[0] == "morphology" {
+		return runImportMorphology(app, args[1:])
+	}
+	if len(args) > 0 && args[0] == "riwayah" {
+		return runImportRiwayah(app, args[1:])
+	}
+	if len(args) == 0 || args[0] != "tanzil" {
+		return fmt.Errorf("unknown import source\n\n%s", usage)
+	}
+
+	var target, edition, script, format, file string
+	rest := args[1:]
+	for i := 0; i < len(rest); i++ {
+		switch rest[i] {
+		case "--target":
+			if i+1 < len(rest) {
+				target = rest[i+1]
+				i++
+			}
+		case "--edition":
+			if i+1 < len(rest) {
+				edition = rest[i+1]
+				i++
+			}
+		case "--script":
+			if i+1 < len(rest) {
+				script = rest[i+1]
+				i++
+			}
+		case "--format":
+			if i+1 < len(rest) {
+				format = rest[i+1]
+				i++
+			}
+		default:
+			file = rest[i]
+		}
+	}
+
+	if target == "" || file == "" {
+		return fmt.Errorf("import tanzil requires --target and a file\n\n%s", usage)
+	}
+
+	verses, err := database.ParseTanzilFile(file, format)
+	if err != nil {
+		return fmt.Errorf("parse %s: %w", file, err)
+	}
+
+	var report *database.ImportReport
+	if script != "" && script != domain.DefaultScript {
+		if target != database.ImportTargetArabic {
+			return fmt.Errorf("--script can only be used with --target arabic")
+		}
+		report, err = database.ImportTanzilScript(app.DB, verses, script)
+	} else if edition != "" && edition != domain.DefaultTranslation {
+		if target != database.ImportTargetTranslation {
+			return fmt.Errorf("--edition can only be used with --target translation")
+		}
+		report, err = database.ImportTanzilTranslation(app.DB, verses, edition)
+	} else {
+		report, err = database.ImportTanzil(app.DB, verses, target, cfg.AudioURLTemplate)
+	}
+	if err != nil {
+		return err
+	}
+
+	into := report.Target
+	if report.Edition != "" {
+		into += " (" + report.Edition + ")"
+	}
+	fmt.Printf("Imported %d verse(s) into %s: %d inserted, %d updated, %d unchanged, %d skipped\n",
+		report.Verses, into, report.Inserted, report.Updated, report.Unchanged, len(report.Skipped))
+	if len(report.Skipped) > 0 {
+		fmt.Printf("Skipped (ayah not in database yet): %s\n", strings.Join(report.Skipped, ", "))
+	}
+
+	if report.Inserted > 0 || report.Updated > 0 {
+		clearQuranCache(app)
+	}
+	return nil
+}
+
+func runImportTafsir(app *App, args []string) error {
+	if len(args) != 1 {
+		return fmt.Errorf("import tafsir requires exactly one file\n\n%s", usage)
+	}
+
+	file, err := database.ParseTafsirFile(args[0])
+	if err != nil {
+		return fmt.Errorf("parse %s: %w", args[0], err)
+	}
+
+	report, err := database.ImportTafsir(app.DB, file)
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("Imported tafsir %s: %d entries, %d inserted, %d updated, %d unchanged, %d deleted\n",
+		report.Edition, report.Entries, report.Inserted, report.Updated, report.Unchanged, report.Deleted)
+
+	if report.Inserted > 0 || report.Updated > 0 || report.Deleted > 0 {
+		clearQuranCache(app)
+	}
+	return nil
+}
+
+func runImportMorphology(app *App, args []string) error {
+	if len(args) != 1 {
+		return fmt.Errorf("import morphology requires exactly one file\n\n%s", usage)
+	}
+
+	segments, err := database.ParseMorphologyFile(args[0])
+	if err != nil {
+		return fmt.Errorf("parse %s: %w", args[0], err)
+	}
+
+	report, err := database.ImportMorphology(app.DB, segments)
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("Imported %d morphology segment(s): %d inserted, %d updated, %d unchanged, %d deleted\n",
+		report.Segments, report.Inserted, report.Updated, report.Unchanged, report.Deleted)
+	if report.Skipped > 0 {
+		fmt.Printf("Skipped %d ayah(s) not in the database yet\n", report.Skipped)
+	}
+
+	if report.Inserted > 0 || report.Updated > 0 || report.Deleted > 0 {
+		clearQuranCache(app)
+	}
+	return nil
+}
+
+func runImportRiwayah(app *App, args []string) error {
+	if len(args) != 1 {
+		return fmt.Errorf("import riwayah requires exactly one file\n\n%s", usage)
+	}
+
+	file, err := database.ParseRiwayahFile(args[0])
+	if err != nil {
+		return fmt.Errorf("parse %s: %w", args[0], err)
+	}
+
+	report, err := database.ImportRiwayah(app.DB, file)
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("Imported riwayah %s: %d surah(s), %d ayahs, %d inserted, %d updated, %d unchanged, %d deleted\n",
+		report.Riwayah, report.Surahs, report.Ayahs, report.Inserted, report.Updated, report.Unchanged, report.Deleted)
+
+	if report.Inserted > 0 || report.Updated > 0 || report.Deleted > 0 {
+		clearQuranCache(app)
+	}
+	return nil
+}
+
+func runDataset(app *App, existing fs.FS, args []string) error {
+	if len(args) == 0 || args[0] != "export" {
+		return fmt.Errorf("unknown dataset action\n\n%s", usage)
+	}
+
+	var out, version string
+	for i := 1; i < len(args); i++ {
+		switch {
+		case args[i] == "--out" && i+1 < len(args):
+			out = args[i+1]
+			i++
+		case args[i] == "--version" && i+1 < len(args):
+			version = args[i+1]
+			i++
+		}
+	}
+
+	if out == "" {
+		return fmt.Errorf("dataset export requires --out DIR")
+	}
+
+	// Nama file mengikuti corpus seed yang sedang dipakai agar hasil ekspor bisa di-diff langsung
+	manifest, err := database.ExportDataset(app.DB, out, version, existing)
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("Exported %d surah(s), %d ayah(s) in %d file(s) to %s (dataset version %s)\n",
+		manifest.Surahs, manifest.TotalAyahs, len(manifest.Files), out, manifest.Version)
+	return nil
+}
+
+func printDriftReport(report *database.DriftReport) {
+	if len(report.Issues) == 0 {
+		fmt.Println("No schema drift detected")
+		return
+	}
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "SEVERITY\tSOURCE\tTABLE\tCOLUMN\tMESSAGE")
+	for _, issue := range report.Issues {
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\n", issue.Severity, issue.Source, dash(issue.Table), dash(issue.Column), issue.Message)
+	}
+	w.Flush()
+}
+
+func dash(value string) string {
+	if value == "" {
+		return "-"
+	}
+	return value
+}
+
+func printMigrationStatus(statuses []database.MigrationStatus) {
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "VERSION\tNAME\tSTATUS\tAPPLIED AT\tDOWN")
+
+	for _, s := range statuses {
+		state := "pending"
+		appliedAt := "-"
+		if s.Applied {
+			state = "applied"
+			appliedAt = s.AppliedAt.Format("2006-01-02 15:04:05")
+		}
+		if s.Modified {
+			state = "modified"
+		}
+		if s.Missing {
+			state = "missing"
+		}
+
+		down := "no"
+		if s.Reversible {
+			down = "yes"
+		}
+
+		fmt.Fprintf(w, "%03d\t%s\t%s\t%s\t%s\n", s.Version, s.Name, state, appliedAt, down)
+	}
+
+	w.Flush()
+}
+
+func printJSON(v interface{}) error {
+	enc := json.NewEncoder(os.Stdout)
+	enc.SetIndent("", "  ")
+	return enc.Encode(v)
+}
+
+// requireMigrated gagal jika masih ada migrasi yang belum dijalankan atau file migrasi yang sudah
+// dijalankan berubah, agar seeding tidak berhenti di tengah karena tabel belum ada
+func requireMigrated(m *database.Migrator) error {
+	migrations, err := m.Load()
+	if err != nil {
+		return err
+	}
+	applied, err := m.Applied()
+	if err != nil {
+		return err
+	}
+	if err := m.Verify(migrations, applied); err != nil {
+		return err
+	}
+
+	pending := 0
+	for _, mig := range migrations {
+		if _, ok := applied[mig.Version]; !ok {
+			pending++
+		}
+	}
+	if pending > 0 {
+		return fmt.Errorf("database has %d pending migration(s), run `server migrate up` first", pending)
+	}
+	return nil
+}
+
+func hasFlag(args []string, flag string) bool {
+	for _, arg := range args {
+		if arg == flag {
+			return true
+		}
+	}
+	return false
+}
+
+func parsePositiveArg(value, name string) (int, error) {
+	n, err := strconv.Atoi(value)
+	if err != nil || n <= 0 {
+		return 0, fmt.Errorf("%s must be a positive number, got %q", name, value)
+	}
+	return n, nil
+}
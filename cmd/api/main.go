@@ -3,6 +3,7 @@ package main
 import (
 	"context" // Tambahkan import context
 	"flag"
+	"fmt"
 	"net"
 
 	"github.com/gin-gonic/gin"
@@ -12,7 +13,6 @@ import (
 	"gorm.io/gorm"
 
 	"khalif-alquran/internal/config"
-	"khalif-alquran/internal/domain"
 	"khalif-alquran/internal/handler"
 	grpcHandler "khalif-alquran/internal/handler/grpc" // Alias untuk membedakan dengan handler HTTP
 	"khalif-alquran/pkg/database"
@@ -38,12 +38,16 @@ import (
 // @in header
 // @name Authorization
 type App struct {
-	DB               *gorm.DB
-	RDB              *redis.Client
-	QuranHandler     *handler.QuranHandler
-	BookmarkHandler  *handler.BookmarkHandler
-	GrpcQuranHandler *grpcHandler.QuranHandler // Field baru untuk gRPC Handler
-	Cfg              *config.Config
+	DB                *gorm.DB
+	RDB               *redis.Client
+	QuranHandler      *handler.QuranHandler
+	BookmarkHandler   *handler.BookmarkHandler
+	MushafHandler     *handler.MushafHandler
+	TafsirHandler     *handler.TafsirHandler
+	MorphologyHandler *handler.MorphologyHandler
+	TajwidHandler     *handler.TajwidHandler
+	GrpcQuranHandler  *grpcHandler.QuranHandler // Field baru untuk gRPC Handler
+	Cfg               *config.Config
 }
 
 // NewApp diperbarui untuk menerima gRPC Handler dari Wire
@@ -52,14 +56,22 @@ func NewApp(
 	rdb *redis.Client,
 	qh *handler.QuranHandler,
 	bh *handler.BookmarkHandler,
+	mh *handler.MushafHandler,
+	th *handler.TafsirHandler,
+	moh *handler.MorphologyHandler,
+	tjh *handler.TajwidHandler,
 	gqh *grpcHandler.QuranHandler, // Parameter baru
 ) *App {
 	return &App{
-		DB:               db,
-		RDB:              rdb,
-		QuranHandler:     qh,
-		BookmarkHandler:  bh,
-		GrpcQuranHandler: gqh, // Assign ke struct
+		DB:                db,
+		RDB:               rdb,
+		QuranHandler:      qh,
+		BookmarkHandler:   bh,
+		MushafHandler:     mh,
+		TafsirHandler:     th,
+		MorphologyHandler: moh,
+		TajwidHandler:     tjh,
+		GrpcQuranHandler:  gqh, // Assign ke struct
 	}
 }
 
@@ -67,8 +79,17 @@ func main() {
 	logger.Init()
 
 	refreshFlag := flag.Bool("refresh", false, "Reset Database")
+	flag.Usage = func() { fmt.Fprint(flag.CommandLine.Output(), usage) }
 	flag.Parse()
 
+	// Perintah offline (misal: `server seed validate`) tidak butuh database maupun config
+	if handled, err := runOfflineCommand(flag.Args()); handled {
+		if err != nil {
+			logger.Fatal("Command failed", zap.Strings("args", flag.Args()), zap.Error(err))
+		}
+		return
+	}
+
 	cfg := config.LoadConfig()
 
 	// Initialize App via Wire (dependency injection)
@@ -92,16 +113,22 @@ func main() {
 		}
 	}
 
-	// AutoMigrate Database Tables
-	app.DB.AutoMigrate(
-		&domain.Surah{},
-		&domain.Ayah{},
-		&domain.Bookmark{},
-	)
+	// Subcommand CLI (misal: `server migrate status`) dijalankan lalu keluar tanpa start server
+	if flag.NArg() > 0 {
+		if err := runCommand(app, cfg, flag.Args()); err != nil {
+			logger.Fatal("Command failed", zap.Strings("args", flag.Args()), zap.Error(err))
+		}
+		return
+	}
+
+	// Skema database sepenuhnya dikelola oleh migrasi SQL (tanpa AutoMigrate)
+	database.RunMigrations(app.DB)
+	database.ReportSchemaDrift(app.DB, cfg.SchemaStrict)
 
 	// Seeding Data
-	database.RunMigrations(app.DB)
-	database.SeedQuran(app.DB)
+	if report := database.SeedQuran(app.DB, database.SeedSource(cfg.SeedDir), cfg.AudioURLTemplate); report != nil && report.Changed() {
+		clearQuranCache(app)
+	}
 
 	// --- Jalankan gRPC Server (Concurrent) ---
 	go func() {
@@ -127,7 +154,7 @@ func main() {
 	r.Use(gin.Recovery())
 
 	// Register Routes HTTP
-	RegisterRoutes(r, app.QuranHandler, app.BookmarkHandler)
+	RegisterRoutes(r, app.QuranHandler, app.BookmarkHandler, app.MushafHandler, app.TafsirHandler, app.MorphologyHandler, app.TajwidHandler)
 
 	// Tentukan Port HTTP
 	port := cfg.Port
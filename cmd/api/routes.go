@@ -14,6 +14,10 @@ func RegisterRoutes(
 	r *gin.Engine,
 	quranHandler *handler.QuranHandler,
 	bookmarkHandler *handler.BookmarkHandler,
+	mushafHandler *handler.MushafHandler,
+	tafsirHandler *handler.TafsirHandler,
+	morphologyHandler *handler.MorphologyHandler,
+	tajwidHandler *handler.TajwidHandler,
 ) {
 	r.Use(middleware.Logger())
 	r.Use(gin.Recovery())
@@ -26,7 +30,43 @@ func RegisterRoutes(
 		{
 			quran.GET("/surahs", quranHandler.GetAllSurahs)
 			quran.GET("/surahs/:number", quranHandler.GetSurahDetail)
+			quran.GET("/surahs/:number/ayahs/:ayah", quranHandler.GetAyahDetail)
+			quran.GET("/surahs/:number/words", quranHandler.GetSurahWords)
+			quran.GET("/ayahs/:global_number", quranHandler.GetAyahByGlobalNumber)
+			quran.GET("/juz/:number", quranHandler.GetJuz)
+			quran.GET("/hizb/:number", quranHandler.GetHizb)
+			quran.GET("/rub/:number", quranHandler.GetRub)
+			quran.GET("/manzil/:number", quranHandler.GetManzil)
+			quran.GET("/ruku/:number", quranHandler.GetRuku)
+			quran.GET("/sajdahs", quranHandler.GetSajdahs)
+			quran.GET("/translations", quranHandler.GetTranslations)
+			quran.GET("/riwayat", quranHandler.GetRiwayat)
 			quran.GET("/search", quranHandler.Search)
+			quran.GET("/resolve", quranHandler.ResolveReference)
+		}
+
+		mushaf := api.Group("/mushaf")
+		{
+			mushaf.GET("", mushafHandler.GetLayouts)
+			mushaf.GET("/:layout/pages/:page", mushafHandler.GetPage)
+			mushaf.GET("/:layout/surahs/:surah/ayahs/:ayah", mushafHandler.GetAyahLocation)
+		}
+
+		tafsir := api.Group("/tafsir")
+		{
+			tafsir.GET("", tafsirHandler.GetEditions)
+			tafsir.GET("/:edition/:surah/:ayah", tafsirHandler.GetEntry)
+		}
+
+		morphology := api.Group("/morphology")
+		{
+			morphology.GET("/roots", morphologyHandler.GetRoots)
+			morphology.GET("/roots/:root", morphologyHandler.GetRoot)
+		}
+
+		tajwid := api.Group("/tajwid")
+		{
+			tajwid.GET("/rules", tajwidHandler.GetRules)
 		}
 
 		bookmarks := api.Group("/bookmarks")
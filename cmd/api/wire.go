@@ -23,22 +23,50 @@ func InitializeApp() (*App, error) {
 
 		repository.NewSurahRepository,
 		repository.NewAyahRepository,
+		repository.NewDivisionRepository,
+		repository.NewMushafRepository,
+		repository.NewTranslationRepository,
+		repository.NewTafsirRepository,
+		repository.NewWordRepository,
+		repository.NewMorphologyRepository,
+		repository.NewRiwayahRepository,
+		repository.NewTajwidRepository,
 		repository.NewRedisRepository,
 		repository.NewBookmarkRepository,
 
 		wire.Bind(new(domain.SurahRepository), new(*repository.SurahRepository)),
 		wire.Bind(new(domain.AyahRepository), new(*repository.AyahRepository)),
+		wire.Bind(new(domain.DivisionRepository), new(*repository.DivisionRepository)),
+		wire.Bind(new(domain.MushafRepository), new(*repository.MushafRepository)),
+		wire.Bind(new(domain.TranslationRepository), new(*repository.TranslationRepository)),
+		wire.Bind(new(domain.TafsirRepository), new(*repository.TafsirRepository)),
+		wire.Bind(new(domain.WordRepository), new(*repository.WordRepository)),
+		wire.Bind(new(domain.MorphologyRepository), new(*repository.MorphologyRepository)),
+		wire.Bind(new(domain.RiwayahRepository), new(*repository.RiwayahRepository)),
+		wire.Bind(new(domain.TajwidRepository), new(*repository.TajwidRepository)),
 		wire.Bind(new(domain.RedisRepository), new(*repository.RedisRepository)),
 		wire.Bind(new(domain.BookmarkRepository), new(*repository.BookmarkRepository)),
 
 		usecase.NewQuranUseCase,
 		usecase.NewBookmarkUseCase,
+		usecase.NewMushafUseCase,
+		usecase.NewTafsirUseCase,
+		usecase.NewMorphologyUseCase,
+		usecase.NewTajwidUseCase,
 
 		wire.Bind(new(domain.QuranUseCase), new(*usecase.QuranUC)),
 		wire.Bind(new(domain.BookmarkUseCase), new(*usecase.BookmarkUC)),
+		wire.Bind(new(domain.MushafUseCase), new(*usecase.MushafUC)),
+		wire.Bind(new(domain.TafsirUseCase), new(*usecase.TafsirUC)),
+		wire.Bind(new(domain.MorphologyUseCase), new(*usecase.MorphologyUC)),
+		wire.Bind(new(domain.TajwidUseCase), new(*usecase.TajwidUC)),
 
 		handler.NewQuranHandler,
 		handler.NewBookmarkHandler,
+		handler.NewMushafHandler,
+		handler.NewTafsirHandler,
+		handler.NewMorphologyHandler,
+		handler.NewTajwidHandler,
 		grpcHandler.NewQuranHandler,
 
 		NewApp,
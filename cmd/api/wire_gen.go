@@ -22,13 +22,29 @@ func InitializeApp() (*App, error) {
 	client := ProvideRedis(configConfig)
 	surahRepository := repository.NewSurahRepository(db)
 	ayahRepository := repository.NewAyahRepository(db)
+	divisionRepository := repository.NewDivisionRepository(db)
+	translationRepository := repository.NewTranslationRepository(db)
+	wordRepository := repository.NewWordRepository(db)
+	riwayahRepository := repository.NewRiwayahRepository(db)
+	tajwidRepository := repository.NewTajwidRepository(db)
 	redisRepository := repository.NewRedisRepository(client)
-	quranUC := usecase.NewQuranUseCase(surahRepository, ayahRepository, redisRepository)
+	quranUC := usecase.NewQuranUseCase(surahRepository, ayahRepository, divisionRepository, translationRepository, wordRepository, riwayahRepository, tajwidRepository, redisRepository)
 	quranHandler := handler.NewQuranHandler(quranUC)
 	bookmarkRepository := repository.NewBookmarkRepository(db)
 	bookmarkUC := usecase.NewBookmarkUseCase(bookmarkRepository)
 	bookmarkHandler := handler.NewBookmarkHandler(bookmarkUC)
+	mushafRepository := repository.NewMushafRepository(db)
+	mushafUC := usecase.NewMushafUseCase(mushafRepository, ayahRepository, redisRepository)
+	mushafHandler := handler.NewMushafHandler(mushafUC)
+	tafsirRepository := repository.NewTafsirRepository(db)
+	tafsirUC := usecase.NewTafsirUseCase(tafsirRepository, redisRepository)
+	tafsirHandler := handler.NewTafsirHandler(tafsirUC)
+	morphologyRepository := repository.NewMorphologyRepository(db)
+	morphologyUC := usecase.NewMorphologyUseCase(morphologyRepository, redisRepository)
+	morphologyHandler := handler.NewMorphologyHandler(morphologyUC)
+	tajwidUC := usecase.NewTajwidUseCase(tajwidRepository, redisRepository)
+	tajwidHandler := handler.NewTajwidHandler(tajwidUC)
 	grpcQuranHandler := grpc.NewQuranHandler(quranUC)
-	app := NewApp(db, client, quranHandler, bookmarkHandler, grpcQuranHandler)
+	app := NewApp(db, client, quranHandler, bookmarkHandler, mushafHandler, tafsirHandler, morphologyHandler, tajwidHandler, grpcQuranHandler)
 	return app, nil
 }
@@ -3,6 +3,7 @@ package config
 import (
 	"log"
 	"os"
+	"strconv"
 
 	"github.com/spf13/viper"
 
@@ -13,6 +14,19 @@ type Config struct {
 	RedisAddr string `mapstructure:"REDIS_ADDR"`
 	Port      string `mapstructure:"PORT"`
 	JWTSecret string `mapstructure:"JWT_SECRET"`
+
+	// SeedDir: override folder data seed (struktur sama dengan pkg/database/seeds).
+	// Kosong berarti memakai data yang di-embed ke binary.
+	SeedDir string `mapstructure:"SEED_DIR"`
+
+	// SchemaStrict: aplikasi gagal start jika skema database berbeda dari migrasi/struct domain
+	SchemaStrict bool `mapstructure:"SCHEMA_STRICT"`
+
+	// AudioURLTemplate: pola audio_url per ayat yang diisi saat seeding, misal
+	// https://everyayah.com/data/Alafasy_128kbps/{surah}{ayah}.mp3 atau /audio/{global}.mp3.
+	// Placeholder {surah} dan {ayah} (3 digit) serta {global} (nomor ayat 1-6236).
+	// Kosong berarti audio_url tidak diisi.
+	AudioURLTemplate string `mapstructure:"AUDIO_URL_TEMPLATE"`
 }
 
 func LoadConfig() *Config {
@@ -47,6 +61,16 @@ func LoadConfig() *Config {
 		config.JWTSecret = os.Getenv("JWT_SECRET")
 	}
 
+	if config.SeedDir == "" {
+		config.SeedDir = os.Getenv("SEED_DIR")
+	}
+	if !config.SchemaStrict {
+		config.SchemaStrict, _ = strconv.ParseBool(os.Getenv("SCHEMA_STRICT"))
+	}
+	if config.AudioURLTemplate == "" {
+		config.AudioURLTemplate = os.Getenv("AUDIO_URL_TEMPLATE")
+	}
+
 	if config.DBUrl == "" {
 		log.Fatal("FATAL: DATABASE_URL is empty. Please check your docker-compose.yml")
 	}
@@ -6,6 +6,127 @@ const (
 	RoleUser  = "User"
 
 	// Cache Keys khusus Al-Quran
-	CacheKeySurahAll    = "quran:surahs:all"   // Untuk list semua surah
-	CacheKeySurahPrefix = "quran:surah:"       // Untuk detail per surah (misal: quran:surah:1)
-)
\ No newline at end of file
+	CacheKeySurahAll          = "quran:surahs:all"       // Untuk list semua surah
+	CacheKeySurahPrefix       = "quran:surah:"           // Untuk detail per surah per riwayat dan rasm (misal: quran:surah:hafs:uthmani:1)
+	CacheKeyDivisionPrefix    = "quran:division:"        // Untuk isi juz/hizb/dst (misal: quran:division:juz:30)
+	CacheKeyMushafPrefix      = "quran:mushaf:"          // Untuk halaman mushaf (misal: quran:mushaf:madani:1)
+	CacheKeySajdahAll         = "quran:division:sajdahs" // Daftar ayat sajdah, ikut terhapus bersama CacheKeyDivisionPrefix
+	CacheKeyTranslationPrefix = "quran:translation:"     // Untuk terjemahan per edisi per surah (misal: quran:translation:en.sahih:1)
+	CacheKeyTafsirPrefix      = "quran:tafsir:"          // Untuk tafsir per ayat (misal: quran:tafsir:id.jalalayn:2:255)
+	CacheKeyWordPrefix        = "quran:words:"           // Untuk kata per kata per surah (misal: quran:words:1)
+	CacheKeyMorphologyPrefix  = "quran:morphology:"      // Untuk kemunculan akar kata (misal: quran:morphology:root:كتب)
+	CacheKeyMorphologyRoots   = "quran:morphology:roots" // Frekuensi semua akar kata, ikut terhapus bersama CacheKeyMorphologyPrefix
+	CacheKeyRiwayahPrefix     = "quran:riwayah:"         // Untuk jumlah ayat per surah per riwayat (misal: quran:riwayah:warsh:counts)
+	CacheKeyTajwidPrefix      = "quran:tajwid:"          // Untuk anotasi tajwid per rasm per surah (misal: quran:tajwid:uthmani:1)
+	CacheKeyTajwidRules       = "quran:tajwid:rules"     // Katalog hukum tajwid, ikut terhapus bersama CacheKeyTajwidPrefix
+)
+
+// Jenis pembagian mushaf di tabel divisions
+const (
+	DivisionJuz    = "juz"
+	DivisionHizb   = "hizb"
+	DivisionRub    = "rub" // rub' al-hizb (seperempat hizb)
+	DivisionManzil = "manzil"
+	DivisionRuku   = "ruku"
+	DivisionPage   = "page" // halaman mushaf DefaultMushafLayout
+)
+
+// Hukum sajdah tilawah per mazhab (domain.SajdahMadhhab)
+const (
+	SajdahObligatory  = "obligatory"
+	SajdahRecommended = "recommended"
+	SajdahNone        = "none"
+)
+
+// Edisi terjemahan yang teksnya ada di kolom ayahs.translation (json "translation_id")
+const DefaultTranslation = "id.kemenag"
+
+// Edisi rasm teks Arab. Teks DefaultScript ada di kolom ayahs.text_arabic, sisanya di ayah_scripts.
+// ScriptIndopak dipakai untuk rasm Mushaf Standar Indonesia (Kemenag).
+const (
+	ScriptUthmani = "uthmani"
+	ScriptImlaei  = "imlaei"
+	ScriptIndopak = "indopak"
+
+	DefaultScript = ScriptUthmani
+)
+
+// Riwayat qira'at. Teks dan penomoran DefaultRiwayah ada di tabel ayahs (Kufi, 6236 ayat);
+// riwayat lain dimuat dari riwayat/<code>.json ke riwayah_mappings dan riwayah_ayahs.
+const (
+	RiwayahHafs  = "hafs"  // Hafs 'an 'Ashim
+	RiwayahWarsh = "warsh" // Warsh 'an Nafi'
+	RiwayahQalun = "qalun" // Qalun 'an Nafi'
+
+	DefaultRiwayah = RiwayahHafs
+)
+
+// Bentuk edisi tafsir
+const (
+	TafsirFormShort = "short"
+	TafsirFormLong  = "long"
+)
+
+// Kelompok hukum tajwid di katalog tajwid_rules
+const (
+	TajwidNunSakinah = "nun_sakinah" // nun sakinah dan tanwin
+	TajwidMimSakinah = "mim_sakinah"
+	TajwidGhunnah    = "ghunnah"
+	TajwidQalqalah   = "qalqalah"
+	TajwidMad        = "mad"
+	TajwidLam        = "lam" // alif lam ta'rif dan lafaz Allah
+	TajwidWaqf       = "waqf"
+)
+
+// Kolom yang dicari oleh pencarian teks penuh (SearchHighlight.Field)
+const (
+	SearchFieldTranslation = "translation"
+	SearchFieldLatin       = "latin"
+	SearchFieldTafsir      = "tafsir"
+	SearchFieldArabic      = "arabic" // text_arabic, dicocokkan tanpa harakat (utils.NormalizeArabic)
+)
+
+// Jumlah ayat per halaman hasil pencarian (?limit=)
+const (
+	SearchDefaultLimit = 20
+	SearchMaxLimit     = 100
+)
+
+// Jenis turunnya surah (kolom surahs.revelation_type)
+const (
+	RevelationMeccan  = "Makkiyah"
+	RevelationMedinan = "Madaniyah"
+)
+
+// Mode render teks ayat (?render=). RenderTajwid memecah text_arabic menjadi span berwarna.
+const RenderTajwid = "tajwid"
+
+// Jenis segmen kata pada data morfologi (Quranic Arabic Corpus)
+const (
+	MorphologyPrefix = "prefix"
+	MorphologyStem   = "stem"
+	MorphologySuffix = "suffix"
+)
+
+// Layout mushaf yang dipakai untuk Ayah.Page dan pembagian "page"
+const DefaultMushafLayout = "madani"
+
+// Jenis baris pada halaman mushaf
+const (
+	MushafLineSurahName = "surah_name"
+	MushafLineBasmallah = "basmallah"
+	MushafLineAyah      = "ayah"
+)
+
+// DivisionTotals adalah jumlah tiap jenis pembagian. Ruku' tidak dicantumkan karena
+// jumlahnya berbeda antar tradisi mushaf, batasnya mengikuti data yang di-seed.
+var DivisionTotals = map[string]int{
+	DivisionJuz:    30,
+	DivisionHizb:   60,
+	DivisionRub:    240,
+	DivisionManzil: 7,
+	DivisionPage:   604,
+}
+
+// Jumlah ayat Al-Quran menurut penomoran Kufi (riwayat Hafs), batas atas number_in_quran
+const TotalQuranAyahs = 6236
\ No newline at end of file
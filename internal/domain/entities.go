@@ -27,8 +27,19 @@ func (t TajwidList) Value() (driver.Value, error) {
 
 // Scan: Mengubah data JSON dari Database menjadi struct Go (GORM interface)
 func (t *TajwidList) Scan(value interface{}) error {
-	bytes, ok := value.([]byte)
-	if !ok {
+	// Kolom tajwid_info boleh NULL (baris lama sebelum kolom ditambahkan)
+	if value == nil {
+		*t = nil
+		return nil
+	}
+
+	var bytes []byte
+	switch v := value.(type) {
+	case []byte:
+		bytes = v
+	case string:
+		bytes = []byte(v)
+	default:
 		return errors.New("type assertion to []byte failed")
 	}
 	return json.Unmarshal(bytes, &t)
@@ -36,9 +47,11 @@ func (t *TajwidList) Scan(value interface{}) error {
 
 // --- Entities ---
 
+// Skema tabel dikelola oleh file SQL di pkg/database/schema, bukan AutoMigrate.
+// Tag gorm di bawah harus tetap sesuai dengan skema tersebut (dicek oleh `server schema check`).
+
 type Surah struct {
-	ID             uint      `gorm:"primaryKey" json:"-"`
-	Number         int       `gorm:"uniqueIndex" json:"number"`
+	Number         int       `gorm:"primaryKey;autoIncrement:false" json:"number"`
 	Name           string    `json:"name"`
 	LatinName      string    `json:"latin_name"`
 	EnglishName    string    `json:"english_name"`
@@ -47,42 +60,397 @@ type Surah struct {
 	
 	// Tag json disesuaikan dengan key di file seed ("ayah_count")
 	TotalAyahs     int       `json:"ayah_count"` 
+
+	// Kelengkapan konten offline: dihitung dari tabel ayahs saat query, bukan kolom tersimpan
+	AvailableAyahs int       `gorm:"->;-:migration" json:"available_ayahs"`
+	IsComplete     bool      `gorm:"->;-:migration" json:"is_complete"`
 	
-	Ayahs          []Ayah    `gorm:"foreignKey:SurahID" json:"ayahs,omitempty"`
+	Ayahs          []Ayah    `gorm:"foreignKey:SurahID;references:Number" json:"ayahs,omitempty"`
 	CreatedAt      time.Time `gorm:"autoCreateTime" json:"created_at"`
 	UpdatedAt      time.Time `gorm:"autoUpdateTime" json:"updated_at"`
 }
 
 type Ayah struct {
-	ID           uint       `gorm:"primaryKey" json:"-"`
-	SurahID      uint       `gorm:"index" json:"surah_id"`
-	Surah        Surah      `gorm:"foreignKey:SurahID" json:"-"`
-	Number       int        `json:"number"`
-	TextArabic   string     `gorm:"type:text" json:"text_arabic"`
-	TextLatin    string     `gorm:"type:text" json:"text_latin"`
+	ID            uint       `gorm:"primaryKey" json:"-"`
+	SurahID       uint       `json:"surah_id"` // Merujuk ke surahs.number
+	Surah         Surah      `gorm:"foreignKey:SurahID;references:Number" json:"-"`
+	Number        int        `json:"number"`
+	NumberInQuran int        `json:"number_in_quran"` // Nomor ayat global (1-6236), dihitung saat seeding
+	Juz           int        `json:"juz"`
+	Hizb          int        `json:"hizb"`
+	Page          int        `json:"page"` // Halaman pada DefaultMushafLayout
+	TextArabic    string     `gorm:"type:text" json:"text_arabic"` // Rasm DefaultScript; edisi lain di ayah_scripts
+	TextNormalized string    `gorm:"type:text" json:"-"` // TextArabic tanpa harakat untuk pencarian, diisi saat seeding
+	TextLatin     string     `gorm:"type:text" json:"text_latin"`
 	
 	// Tag json disesuaikan dengan key di file seed ("translation_id")
-	Translation  string     `gorm:"type:text" json:"translation_id"`
+	Translation   string     `gorm:"type:text" json:"translation_id"`
 	
-	Tafsir       string     `gorm:"type:text" json:"tafsir"`
-	AsbabunNuzul string     `gorm:"type:text" json:"asbabun_nuzul"`
+	Tafsir        string     `gorm:"type:text" json:"tafsir"`
+	AsbabunNuzul  string     `gorm:"type:text" json:"asbabun_nuzul"`
 	
 	// Menggunakan tipe custom TajwidList dan tag "tajwid_info"
 	// Tipe gorm:jsonb agar tersimpan efisien di Postgres
-	TajwidInfo   TajwidList `gorm:"type:jsonb" json:"tajwid_info"` 
+	TajwidInfo    TajwidList `gorm:"type:jsonb" json:"tajwid_info"` 
+
+	// Diisi saat seeding dari AUDIO_URL_TEMPLATE jika masih kosong
+	AudioURL      string     `json:"audio_url"`
+
+	// Hanya terisi untuk 15 ayat sajdah tilawah
+	Sajdah        *Sajdah    `gorm:"foreignKey:SurahID,AyahNumber;references:SurahID,Number" json:"sajdah,omitempty"`
+
+	// Terjemahan tambahan sesuai parameter ?translations=, urut seperti permintaan
+	Translations  []AyahTranslation `gorm:"-" json:"translations,omitempty"`
+
+	// Edisi rasm dari TextArabic, hanya diisi pada detail surah/ayat (lihat AyahOptions.Script)
+	Script        string     `gorm:"-" json:"script,omitempty"`
+
+	// Hanya diisi jika riwayat selain Hafs diminta: Number mengikuti penomoran riwayat tersebut,
+	// HafsNumbers berisi nomor ayat Hafs (surah yang sama) yang dicakup ayat ini. Jika satu ayat Hafs
+	// dipecah menjadi beberapa ayat riwayat, HafsPart berisi urutan bagiannya (1, 2, ...) dan terjemahan,
+	// tafsir serta asbabun nuzul hanya diisi pada bagian pertama agar tidak tampil dua kali.
+	Riwayah       string     `gorm:"-" json:"riwayah,omitempty"`
+	HafsNumbers   []int      `gorm:"-" json:"hafs_numbers,omitempty"`
+	HafsPart      int        `gorm:"-" json:"hafs_part,omitempty"`
+
+	// Kata per kata, hanya diisi jika diminta lewat ?include=words
+	Words         []Word     `gorm:"-" json:"words,omitempty"`
+
+	// Anotasi tajwid untuk TextArabic (edisi Script), hanya diisi lewat ?include=tajwid.
+	// TajwidSpans berisi TextArabic yang sudah dipecah per hukum, hanya diisi lewat ?render=tajwid.
+	Tajwid        []TajwidAnnotation `gorm:"-" json:"tajwid,omitempty"`
+	TajwidSpans   []TajwidSpan `gorm:"-" json:"tajwid_spans,omitempty"`
+
+	// Hanya diisi pada hasil pencarian: teks mana yang cocok beserta cuplikan dan skornya
+	Highlight     *SearchHighlight `gorm:"-" json:"highlight,omitempty"`
 	
-	CreatedAt    time.Time  `gorm:"autoCreateTime" json:"created_at"`
-	UpdatedAt    time.Time  `gorm:"autoUpdateTime" json:"updated_at"`
+	CreatedAt     time.Time  `gorm:"autoCreateTime" json:"created_at"`
+	UpdatedAt     time.Time  `gorm:"autoUpdateTime" json:"updated_at"`
+}
+
+// SurahAlias adalah satu ejaan nama surah yang bisa dicari, termasuk nama-nama di tabel surahs.
+// SearchKey berisi utils.SurahNameKey(Alias) dan dicocokkan dengan similarity pg_trgm.
+type SurahAlias struct {
+	SurahID   int    `gorm:"primaryKey;autoIncrement:false" json:"surah_id"`
+	Alias     string `gorm:"primaryKey" json:"alias"`
+	SearchKey string `json:"search_key"`
+}
+
+// Translation adalah satu edisi terjemahan di katalog (misal "en.sahih").
+// Teks edisi DefaultTranslation tetap disimpan di kolom ayahs.translation.
+type Translation struct {
+	Code       string `gorm:"primaryKey" json:"code"`
+	Language   string `json:"language"` // kode ISO 639-1, misal "id", "en", "ms"
+	Name       string `json:"name"`
+	Translator string `json:"translator"`
+	Source     string `json:"source"`
+	License    string `json:"license"`
+}
+
+type AyahTranslation struct {
+	Edition    string `gorm:"primaryKey" json:"edition"`
+	SurahID    uint   `gorm:"primaryKey;autoIncrement:false" json:"-"`
+	AyahNumber int    `gorm:"primaryKey;autoIncrement:false" json:"-"`
+	Language   string `gorm:"-" json:"language"`
+	Text       string `gorm:"type:text" json:"text"`
+}
+
+// AyahScript adalah teks Arab satu ayat dalam edisi rasm selain DefaultScript
+type AyahScript struct {
+	Script     string `gorm:"primaryKey" json:"script"`
+	SurahID    uint   `gorm:"primaryKey;autoIncrement:false" json:"surah_id"`
+	AyahNumber int    `gorm:"primaryKey;autoIncrement:false" json:"ayah_number"`
+	Text       string `gorm:"type:text" json:"text"`
+}
+
+// Riwayah adalah satu jalur periwayatan qira'at (misal Warsh 'an Nafi'). Teks riwayat DefaultRiwayah
+// ada di tabel ayahs; riwayat lain disimpan di riwayah_ayahs dengan penomoran ayatnya sendiri.
+type Riwayah struct {
+	Code       string `gorm:"primaryKey" json:"code"`
+	Name       string `json:"name"`
+	Qiraah     string `json:"qiraah"`    // imam qira'at, misal "Nafi'"
+	Numbering  string `json:"numbering"` // sistem penghitungan ayat, misal "kufi" atau "madani-2"
+	TotalAyahs int    `json:"total_ayahs"`
+	Source     string `json:"source"`
+	License    string `json:"license"`
+}
+
+// RiwayahMapping memetakan satu ayat riwayat ke rentang ayat Hafs HafsFrom..HafsTo dalam surah yang sama.
+// Ayat Hafs yang dipecah menjadi dua ayat muncul di dua baris dengan rentang yang sama (lihat Ayah.HafsPart);
+// basmalah Al-Fatihah pada penomoran Madani tidak dipetakan sama sekali.
+type RiwayahMapping struct {
+	Riwayah  string `gorm:"primaryKey" json:"riwayah"`
+	SurahID  uint   `gorm:"primaryKey;autoIncrement:false" json:"surah_id"`
+	Number   int    `gorm:"primaryKey;autoIncrement:false" json:"number"`
+	HafsFrom int    `json:"hafs_from"`
+	HafsTo   int    `json:"hafs_to"`
+}
+
+// RiwayahAyah adalah teks Arab satu ayat dalam penomoran riwayatnya sendiri
+type RiwayahAyah struct {
+	Riwayah    string `gorm:"primaryKey" json:"riwayah"`
+	SurahID    uint   `gorm:"primaryKey;autoIncrement:false" json:"surah_id"`
+	Number     int    `gorm:"primaryKey;autoIncrement:false" json:"number"`
+	TextArabic string `gorm:"type:text" json:"text_arabic"`
+}
+
+// Word adalah satu kata dalam ayat (mode kata per kata), diurutkan berdasarkan Position mulai dari 1
+type Word struct {
+	SurahID         uint   `gorm:"primaryKey;autoIncrement:false" json:"surah_id"`
+	AyahNumber      int    `gorm:"primaryKey;autoIncrement:false" json:"ayah_number"`
+	Position        int    `gorm:"primaryKey;autoIncrement:false" json:"position"`
+	TextUthmani     string `json:"text_uthmani"`
+	Transliteration string `json:"transliteration"`
+	TranslationID   string `json:"translation_id"` // arti dalam bahasa Indonesia
+	TranslationEN   string `json:"translation_en"`
+}
+
+// TajwidRuleDefinition adalah satu hukum tajwid di katalog. Code dipakai oleh TajwidAnnotation.Rule,
+// Color adalah warna yang disarankan untuk menampilkannya (hex, misal "#169777").
+type TajwidRuleDefinition struct {
+	Code     string `gorm:"primaryKey" json:"code"`
+	NameID   string `json:"name_id"`
+	NameEN   string `json:"name_en"`
+	Category string `json:"category"` // nun_sakinah | mim_sakinah | ghunnah | qalqalah | mad | lam | waqf
+	Color    string `json:"color"`
+}
+
+func (TajwidRuleDefinition) TableName() string {
+	return "tajwid_rules"
+}
+
+// TajwidAnnotation menandai satu hukum tajwid pada teks Arab suatu edisi rasm. Start dan End adalah
+// offset karakter (rune, bukan byte) dari awal teks ayat di edisi tersebut; End tidak termasuk.
+type TajwidAnnotation struct {
+	Script     string `gorm:"primaryKey" json:"-"`
+	SurahID    uint   `gorm:"primaryKey;autoIncrement:false" json:"-"`
+	AyahNumber int    `gorm:"primaryKey;autoIncrement:false" json:"-"`
+	Start      int    `gorm:"column:start_offset;primaryKey;autoIncrement:false" json:"start"`
+	Rule       string `gorm:"primaryKey" json:"rule"`
+	End        int    `gorm:"column:end_offset" json:"end"`
+}
+
+// TajwidSpan adalah satu potongan teks ayat pada mode render tajwid. Potongan tanpa hukum
+// tidak memiliki Rules dan Color; jika beberapa hukum bertumpuk, Color mengikuti hukum yang rentangnya paling pendek.
+type TajwidSpan struct {
+	Text  string   `json:"text"`
+	Rules []string `json:"rules,omitempty"`
+	Color string   `json:"color,omitempty"`
+}
+
+// MorphologySegment adalah satu segmen kata (awalan, stem atau akhiran) dari Quranic Arabic Corpus.
+// Form, Root dan Lemma disimpan dalam huruf Arab; Features menyimpan kolom FEATURES asli (Buckwalter).
+type MorphologySegment struct {
+	SurahID    uint   `gorm:"primaryKey;autoIncrement:false" json:"surah_id"`
+	AyahNumber int    `gorm:"primaryKey;autoIncrement:false" json:"ayah_number"`
+	Position   int    `gorm:"primaryKey;autoIncrement:false" json:"position"` // posisi kata dalam ayat
+	Segment    int    `gorm:"primaryKey;autoIncrement:false" json:"segment"`
+	Form       string `json:"form"`
+	Tag        string `json:"tag"`  // part of speech, misal N, V, P, PRON
+	Kind       string `json:"kind"` // prefix | stem | suffix
+	Root       string `json:"root,omitempty"`
+	Lemma      string `json:"lemma,omitempty"`
+	Features   string `json:"features"`
+}
+
+// RootOccurrence adalah satu kata yang berasal dari sebuah akar kata
+type RootOccurrence struct {
+	SurahID     uint   `json:"surah_id"`
+	AyahNumber  int    `json:"ayah_number"`
+	Position    int    `json:"position"`
+	Reference   string `json:"reference"` // "surah:ayah", misal "2:255"
+	Form        string `json:"form"`
+	Lemma       string `json:"lemma"`
+	Tag         string `json:"tag"`
+	TextUthmani string `json:"text_uthmani,omitempty"` // dari tabel words jika tersedia
+}
+
+// RootFrequency adalah jumlah kemunculan satu akar kata di seluruh Al-Quran
+type RootFrequency struct {
+	Root        string `json:"root"`
+	Buckwalter  string `json:"buckwalter"`
+	Occurrences int    `json:"occurrences"`
+	Lemmas      int    `json:"lemmas"`
+}
+
+// TafsirEdition adalah satu sumber tafsir (misal Tafsir Jalalayn), dalam bentuk ringkas atau panjang
+type TafsirEdition struct {
+	Code     string `gorm:"primaryKey" json:"code"`
+	Language string `json:"language"`
+	Name     string `json:"name"`
+	Author   string `json:"author"`
+	Form     string `json:"form"` // short | long
+	Source   string `json:"source"`
+	License  string `json:"license"`
+}
+
+// TafsirEntry adalah satu penjelasan yang mencakup ayat FromAyah sampai ToAyah dalam satu surah
+type TafsirEntry struct {
+	Edition  string `gorm:"primaryKey" json:"edition"`
+	SurahID  uint   `gorm:"primaryKey;autoIncrement:false" json:"surah_id"`
+	FromAyah int    `gorm:"primaryKey;autoIncrement:false" json:"from_ayah"`
+	ToAyah   int    `json:"to_ayah"`
+	Text     string `gorm:"type:text" json:"text"`
+}
+
+// SearchHighlight menjelaskan bagian ayat yang cocok dengan pencarian teks penuh
+type SearchHighlight struct {
+	Field   string  `json:"field"`             // translation | latin | tafsir | arabic
+	Edition string  `json:"edition,omitempty"` // kode edisi terjemahan/tafsir yang cocok
+	Snippet string  `json:"snippet"`           // cuplikan dengan kata yang cocok diapit <mark></mark>
+	Rank    float64 `json:"rank"`              // ts_rank, makin besar makin relevan
+
+	// Hanya untuk field arabic: posisi kata yang cocok di text_arabic (berharakat), Snippet berisi ayat utuh
+	Matches []TextRange `json:"matches,omitempty"`
+}
+
+// TextRange adalah rentang karakter (rune, bukan byte) [Start, End) pada sebuah teks
+type TextRange struct {
+	Start int `json:"start"`
+	End   int `json:"end"`
+}
+
+// AyahRange adalah rentang nomor ayat From..To (keduanya termasuk) dalam satu surah
+type AyahRange struct {
+	From int `json:"from"`
+	To   int `json:"to"`
+}
+
+// ResolvedReference adalah hasil membaca rujukan ayat seperti "2:255", "QS. Al-Baqarah [2]: 255-257"
+// atau "Yasin 1-12". Reference adalah bentuk bakunya (misal "2:255-257"), Ranges sudah diurutkan dan digabung.
+type ResolvedReference struct {
+	Query     string      `json:"query"`
+	Reference string      `json:"reference"`
+	Surah     *Surah      `json:"surah"`
+	Ranges    []AyahRange `json:"ranges"`
+	Ayahs     []Ayah      `json:"ayahs"`
+}
+
+// SearchOptions adalah filter dan paginasi pencarian. Nilai nol berarti tanpa filter;
+// QuranUC.Search mengisi SurahFrom/SurahTo dan Limit dengan nilai default sebelum ke repository.
+type SearchOptions struct {
+	SurahFrom      int
+	SurahTo        int
+	Juz            int
+	RevelationType string   // RevelationMeccan | RevelationMedinan
+	Fields         []string // SearchField*, kolom yang dicari
+	Cursor         string   // NextCursor dari halaman sebelumnya
+	Limit          int
+	Riwayah        string
+}
+
+// SearchResult adalah satu halaman hasil pencarian. Reference dan Surahs hanya diisi di halaman pertama.
+// TotalAyahs (jumlah ayat yang cocok di semua halaman) dan NextCursor dikirim lewat meta response.
+type SearchResult struct {
+	Reference  *ResolvedReference `json:"reference"`
+	Surahs     []Surah            `json:"surahs"`
+	Ayahs      []Ayah             `json:"ayahs"`
+	TotalAyahs int                `json:"-"`
+	NextCursor string             `json:"-"`
+}
+
+// AyahOptions adalah parameter tambahan untuk detail surah/ayat
+type AyahOptions struct {
+	Translations []string // kode edisi, misal ["id.kemenag", "en.sahih"]
+	Words        bool     // ?include=words
+	Script       string   // uthmani | imlaei | indopak, kosong berarti DefaultScript
+	Riwayah      string   // hafs | warsh | qalun, kosong berarti DefaultRiwayah
+	Tajwid       bool     // ?include=tajwid
+	Render       string   // ?render=, saat ini hanya RenderTajwid
+}
+
+// Division adalah satu pembagian mushaf (juz, hizb, rub', manzil, ruku' atau halaman)
+// yang dinyatakan sebagai rentang ayat
+type Division struct {
+	Type       string `gorm:"primaryKey" json:"type"`
+	Number     int    `gorm:"primaryKey;autoIncrement:false" json:"number"`
+	StartSurah int    `json:"start_surah"`
+	StartAyah  int    `json:"start_ayah"`
+	EndSurah   int    `json:"end_surah"`
+	EndAyah    int    `json:"end_ayah"`
+	FirstAyah  int    `json:"first_number_in_quran"`
+	LastAyah   int    `json:"last_number_in_quran"`
+	Ayahs      []Ayah `gorm:"-" json:"ayahs,omitempty"`
+}
+
+// Sajdah adalah satu ayat sajdah tilawah. Type mengikuti klasifikasi Tanzil,
+// sedangkan Madhhab berisi hukumnya menurut empat mazhab.
+type Sajdah struct {
+	Number     int           `gorm:"primaryKey;autoIncrement:false" json:"number"`
+	SurahID    uint          `json:"surah_id"`
+	AyahNumber int           `json:"ayah_number"`
+	Type       string        `json:"type"` // recommended | obligatory
+	Madhhab    SajdahMadhhab `gorm:"embedded" json:"madhhab"`
+}
+
+// SajdahMadhhab: tiap kolom berisi obligatory | recommended | none
+// (none = mazhab tersebut tidak menghitungnya sebagai sajdah tilawah)
+type SajdahMadhhab struct {
+	Hanafi  string `json:"hanafi"`
+	Maliki  string `json:"maliki"`
+	Shafii  string `json:"shafii"`
+	Hanbali string `json:"hanbali"`
+}
+
+// MushafLayout adalah satu cetakan mushaf, misal Madinah 15 baris atau Indo-Pak 16 baris
+type MushafLayout struct {
+	Code         string `gorm:"primaryKey" json:"code"`
+	Name         string `json:"name"`
+	LinesPerPage int    `json:"lines_per_page"`
+	TotalPages   int    `json:"total_pages"`
+}
+
+type MushafPage struct {
+	Layout     string       `gorm:"primaryKey" json:"layout"`
+	Page       int          `gorm:"primaryKey;autoIncrement:false" json:"page"`
+	StartSurah int          `json:"start_surah"`
+	StartAyah  int          `json:"start_ayah"`
+	EndSurah   int          `json:"end_surah"`
+	EndAyah    int          `json:"end_ayah"`
+	FirstAyah  int          `json:"first_number_in_quran"`
+	LastAyah   int          `json:"last_number_in_quran"`
+	Lines      []MushafLine `gorm:"foreignKey:Layout,Page;references:Layout,Page" json:"lines"`
+	Ayahs      []PageAyah   `gorm:"-" json:"ayahs,omitempty"`
+}
+
+// MushafLine adalah satu baris di halaman. Baris judul surah/basmalah tidak memiliki rentang ayat.
+type MushafLine struct {
+	Layout    string `gorm:"primaryKey" json:"-"`
+	Page      int    `gorm:"primaryKey;autoIncrement:false" json:"-"`
+	Line      int    `gorm:"primaryKey;autoIncrement:false" json:"line"`
+	Type      string `json:"type"` // surah_name | basmallah | ayah
+	SurahID   uint   `json:"surah_id"`
+	FirstAyah *int   `json:"first_number_in_quran,omitempty"`
+	LastAyah  *int   `json:"last_number_in_quran,omitempty"`
+}
+
+// PageAyah adalah ayat pada satu halaman beserta baris tempat ayat itu dimulai dan berakhir
+type PageAyah struct {
+	Ayah
+	LineStart int `json:"line_start,omitempty"`
+	LineEnd   int `json:"line_end,omitempty"`
+}
+
+// MushafLocation menunjukkan letak satu ayat di layout tertentu
+type MushafLocation struct {
+	Layout        string `json:"layout"`
+	Page          int    `json:"page"`
+	SurahNumber   int    `json:"surah_number"`
+	AyahNumber    int    `json:"ayah_number"`
+	NumberInQuran int    `json:"number_in_quran"`
+	LineStart     int    `json:"line_start,omitempty"`
+	LineEnd       int    `json:"line_end,omitempty"`
 }
 
 type Bookmark struct {
 	ID         uint      `gorm:"primaryKey" json:"id"`
-	UserID     string    `gorm:"index" json:"user_id"`
-	SurahID    uint      `gorm:"index" json:"surah_id"`
-	Surah      Surah     `gorm:"foreignKey:SurahID" json:"surah,omitempty"`
+	UserID     string    `json:"user_id"`
+	SurahID    uint      `json:"surah_id"` // Merujuk ke surahs.number
+	Surah      Surah     `gorm:"foreignKey:SurahID;references:Number" json:"surah,omitempty"`
 	AyahNumber int       `json:"ayah_number"`
 	Note       string    `json:"note"`
 	CreatedAt  time.Time `gorm:"autoCreateTime" json:"created_at"`
+	UpdatedAt  time.Time `gorm:"autoUpdateTime" json:"updated_at"`
 }
 
 // --- Interfaces ---
@@ -90,13 +458,68 @@ type Bookmark struct {
 type SurahRepository interface {
 	GetAll(ctx context.Context) ([]Surah, error)
 	GetByNumber(ctx context.Context, number int) (*Surah, error)
-	Search(ctx context.Context, query string) ([]Surah, error)
+	Search(ctx context.Context, query string, opts SearchOptions) ([]Surah, error)
+	FindByName(ctx context.Context, name string) (*Surah, error)
 }
 
 type AyahRepository interface {
 	GetBySurahID(ctx context.Context, surahID uint) ([]Ayah, error)
 	GetSpecificAyah(ctx context.Context, surahNumber, ayahNumber int) (*Ayah, error)
-	Search(ctx context.Context, query string) ([]Ayah, error)
+	GetByGlobalNumber(ctx context.Context, numberInQuran int) (*Ayah, error)
+	GetByGlobalRange(ctx context.Context, first, last int) ([]Ayah, error)
+	Search(ctx context.Context, query string, opts SearchOptions) (*SearchResult, error)
+	GetScriptBySurah(ctx context.Context, script string, surahNumber int) ([]AyahScript, error)
+	GetScriptByAyah(ctx context.Context, script string, surahNumber, ayahNumber int) (*AyahScript, error)
+}
+
+type DivisionRepository interface {
+	GetByNumber(ctx context.Context, divisionType string, number int) (*Division, error)
+	GetSajdahs(ctx context.Context) ([]Sajdah, error)
+}
+
+type TranslationRepository interface {
+	GetAll(ctx context.Context) ([]Translation, error)
+	GetByCodes(ctx context.Context, codes []string) ([]Translation, error)
+	GetBySurah(ctx context.Context, edition string, surahNumber int) ([]AyahTranslation, error)
+	GetByAyah(ctx context.Context, editions []string, surahNumber, ayahNumber int) ([]AyahTranslation, error)
+}
+
+type RiwayahRepository interface {
+	GetAll(ctx context.Context) ([]Riwayah, error)
+	GetByCode(ctx context.Context, code string) (*Riwayah, error)
+	GetAyahCounts(ctx context.Context, riwayah string) (map[int]int, error)
+	GetTextCounts(ctx context.Context, riwayah string) (map[int]int, error)
+	GetMappings(ctx context.Context, riwayah string, surahNumbers []int) ([]RiwayahMapping, error)
+	GetTexts(ctx context.Context, riwayah string, surahNumbers []int) ([]RiwayahAyah, error)
+}
+
+type WordRepository interface {
+	GetBySurah(ctx context.Context, surahNumber int) ([]Word, error)
+	GetByAyah(ctx context.Context, surahNumber, ayahNumber int) ([]Word, error)
+}
+
+type TajwidRepository interface {
+	GetRules(ctx context.Context) ([]TajwidRuleDefinition, error)
+	GetBySurah(ctx context.Context, script string, surahNumber int) ([]TajwidAnnotation, error)
+	GetByAyah(ctx context.Context, script string, surahNumber, ayahNumber int) ([]TajwidAnnotation, error)
+}
+
+type MorphologyRepository interface {
+	GetRootFrequencies(ctx context.Context) ([]RootFrequency, error)
+	GetRootOccurrences(ctx context.Context, root string) ([]RootOccurrence, error)
+}
+
+type TafsirRepository interface {
+	GetEditions(ctx context.Context) ([]TafsirEdition, error)
+	GetEdition(ctx context.Context, code string) (*TafsirEdition, error)
+	GetCoveringEntry(ctx context.Context, edition string, surahNumber, ayahNumber int) (*TafsirEntry, error)
+}
+
+type MushafRepository interface {
+	GetLayouts(ctx context.Context) ([]MushafLayout, error)
+	GetLayout(ctx context.Context, code string) (*MushafLayout, error)
+	GetPage(ctx context.Context, layout string, page int) (*MushafPage, error)
+	GetPageByAyah(ctx context.Context, layout string, numberInQuran int) (*MushafPage, error)
 }
 
 type RedisRepository interface {
@@ -114,13 +537,40 @@ type BookmarkRepository interface {
 }
 
 type QuranUseCase interface {
-	GetAllSurahs(ctx context.Context) ([]Surah, error)
-	GetSurahDetail(ctx context.Context, number int) (*Surah, error)
-	GetAyahDetail(ctx context.Context, surahNumber, ayahNumber int) (*Ayah, error)
-	Search(ctx context.Context, query string) (map[string]interface{}, error)
+	GetAllSurahs(ctx context.Context, riwayah string) ([]Surah, error)
+	GetSurahDetail(ctx context.Context, number int, opts AyahOptions) (*Surah, error)
+	GetAyahDetail(ctx context.Context, surahNumber, ayahNumber int, opts AyahOptions) (*Ayah, error)
+	GetAyahByGlobalNumber(ctx context.Context, numberInQuran int, riwayah string) (*Ayah, error)
+	GetDivision(ctx context.Context, divisionType string, number int, riwayah string) (*Division, error)
+	GetSajdahs(ctx context.Context, riwayah string) ([]Sajdah, error)
+	GetTranslations(ctx context.Context) ([]Translation, error)
+	GetRiwayat(ctx context.Context) ([]Riwayah, error)
+	GetSurahWords(ctx context.Context, number int, riwayah string) ([]Word, error)
+	Search(ctx context.Context, query string, opts SearchOptions) (*SearchResult, error)
+	ResolveReference(ctx context.Context, ref, riwayah string) (*ResolvedReference, error)
 	ClearCache(ctx context.Context) error
 }
 
+type MorphologyUseCase interface {
+	GetRoots(ctx context.Context, riwayah string) ([]RootFrequency, error)
+	GetRoot(ctx context.Context, root, riwayah string) ([]RootOccurrence, error)
+}
+
+type TajwidUseCase interface {
+	GetRules(ctx context.Context) ([]TajwidRuleDefinition, error)
+}
+
+type TafsirUseCase interface {
+	GetEditions(ctx context.Context) ([]TafsirEdition, error)
+	GetEntry(ctx context.Context, edition string, surahNumber, ayahNumber int, riwayah string) (*TafsirEntry, error)
+}
+
+type MushafUseCase interface {
+	GetLayouts(ctx context.Context) ([]MushafLayout, error)
+	GetPage(ctx context.Context, layout string, page int, riwayah string) (*MushafPage, error)
+	GetAyahLocation(ctx context.Context, layout string, surahNumber, ayahNumber int, riwayah string) (*MushafLocation, error)
+}
+
 type BookmarkUseCase interface {
 	AddBookmark(ctx context.Context, userID string, surahID uint, ayahNumber int, note string) error
 	GetUserBookmarks(ctx context.Context, userID string) ([]Bookmark, error)
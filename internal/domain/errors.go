@@ -12,4 +12,18 @@ var (
 	// Error Spesifik Domain Al-Quran (Opsional, agar lebih jelas saat debugging)
 	ErrInvalidSurahNumber  = errors.New("surah number must be between 1 and 114")
 	ErrInvalidAyahNumber   = errors.New("ayah number is out of range for this surah")
+	ErrInvalidGlobalAyah   = errors.New("global ayah number is out of range for this riwayah")
+	ErrInvalidDivision     = errors.New("division number is out of range")
+	ErrInvalidMushafPage   = errors.New("page number is out of range for this mushaf layout")
+	ErrUnknownTranslation  = errors.New("unknown translation edition")
+	ErrInvalidRoot         = errors.New("root must be Arabic letters or Buckwalter transliteration")
+	ErrUnknownScript       = errors.New("unknown script, use uthmani, imlaei or indopak")
+	ErrUnknownRiwayah      = errors.New("unknown riwayah, use hafs, warsh or qalun")
+	ErrRiwayahUnavailable  = errors.New("riwayah data is not loaded, import it with `import riwayah`")
+	ErrRiwayahUnsupported  = errors.New("this data is only available for the hafs riwayah")
+	ErrUnknownRender       = errors.New("unknown render mode, use tajwid")
+	ErrInvalidInclude      = errors.New("unknown include value, use words or tajwid")
+	ErrInvalidReference    = errors.New("invalid ayah reference, use e.g. 2:255, 2:1-5,10 or Al-Baqarah 255")
+	ErrInvalidSearchFilter = errors.New("invalid search filter, check surah_from, surah_to (1-114), juz (1-30), revelation (meccan or medinan), fields and limit")
+	ErrInvalidCursor       = errors.New("invalid or expired search cursor")
 )
\ No newline at end of file
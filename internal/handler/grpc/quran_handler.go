@@ -19,8 +19,8 @@ func NewQuranHandler(quranUC domain.QuranUseCase) *QuranHandler {
 	}
 }
 
-func (h *QuranHandler) GetAllSurahs(ctx context.Context, req *pb.Empty) (*pb.SurahListResponse, error) {
-	surahs, err := h.quranUC.GetAllSurahs(ctx)
+func (h *QuranHandler) GetAllSurahs(ctx context.Context, req *pb.SurahListRequest) (*pb.SurahListResponse, error) {
+	surahs, err := h.quranUC.GetAllSurahs(ctx, req.Riwayah)
 	if err != nil {
 		return nil, err
 	}
@@ -35,6 +35,8 @@ func (h *QuranHandler) GetAllSurahs(ctx context.Context, req *pb.Empty) (*pb.Sur
 			IndonesianName: s.IndonesianName, // Field Baru
 			RevelationType: s.RevelationType,
 			TotalAyahs:     int32(s.TotalAyahs),
+			AvailableAyahs: int32(s.AvailableAyahs),
+			IsComplete:     s.IsComplete,
 		})
 	}
 
@@ -42,7 +44,7 @@ func (h *QuranHandler) GetAllSurahs(ctx context.Context, req *pb.Empty) (*pb.Sur
 }
 
 func (h *QuranHandler) GetSurahDetail(ctx context.Context, req *pb.SurahDetailRequest) (*pb.SurahDetailResponse, error) {
-	surah, err := h.quranUC.GetSurahDetail(ctx, int(req.Number))
+	surah, err := h.quranUC.GetSurahDetail(ctx, int(req.Number), domain.AyahOptions{Words: req.IncludeWords, Script: req.Script, Riwayah: req.Riwayah})
 	if err != nil {
 		return nil, err
 	}
@@ -55,15 +57,27 @@ func (h *QuranHandler) GetSurahDetail(ctx context.Context, req *pb.SurahDetailRe
 		IndonesianName: surah.IndonesianName, // Field Baru
 		RevelationType: surah.RevelationType,
 		TotalAyahs:     int32(surah.TotalAyahs),
+		AvailableAyahs: int32(surah.AvailableAyahs),
+		IsComplete:     surah.IsComplete,
 	}
 
 	var pbAyahs []*pb.Ayah
 	for _, a := range surah.Ayahs {
 		pbAyahs = append(pbAyahs, &pb.Ayah{
-			Number:      int32(a.Number),
-			TextArabic:  a.TextArabic,
-			TextLatin:   a.TextLatin,
-			Translation: a.Translation,
+			Number:        int32(a.Number),
+			TextArabic:    a.TextArabic,
+			TextLatin:     a.TextLatin,
+			Translation:   a.Translation,
+			NumberInQuran: int32(a.NumberInQuran),
+			AudioUrl:      a.AudioURL,
+			Juz:           int32(a.Juz),
+			Hizb:          int32(a.Hizb),
+			Page:          int32(a.Page),
+			Sajdah:        toPbSajdah(a.Sajdah),
+			Words:         toPbWords(a.Words),
+			Script:        a.Script,
+			Riwayah:       a.Riwayah,
+			HafsNumbers:   toPbInts(a.HafsNumbers),
 		})
 	}
 
@@ -71,4 +85,42 @@ func (h *QuranHandler) GetSurahDetail(ctx context.Context, req *pb.SurahDetailRe
 		Surah: pbSurah,
 		Ayahs: pbAyahs,
 	}, nil
+}
+
+func toPbInts(numbers []int) []int32 {
+	var result []int32
+	for _, n := range numbers {
+		result = append(result, int32(n))
+	}
+	return result
+}
+
+func toPbWords(words []domain.Word) []*pb.Word {
+	var pbWords []*pb.Word
+	for _, w := range words {
+		pbWords = append(pbWords, &pb.Word{
+			Position:        int32(w.Position),
+			TextUthmani:     w.TextUthmani,
+			Transliteration: w.Transliteration,
+			TranslationId:   w.TranslationID,
+			TranslationEn:   w.TranslationEN,
+		})
+	}
+	return pbWords
+}
+
+func toPbSajdah(s *domain.Sajdah) *pb.Sajdah {
+	if s == nil {
+		return nil
+	}
+	return &pb.Sajdah{
+		Number: int32(s.Number),
+		Type:   s.Type,
+		Madhhab: &pb.SajdahMadhhab{
+			Hanafi:  s.Madhhab.Hanafi,
+			Maliki:  s.Madhhab.Maliki,
+			Shafii:  s.Madhhab.Shafii,
+			Hanbali: s.Madhhab.Hanbali,
+		},
+	}
 }
\ No newline at end of file
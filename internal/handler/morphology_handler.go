@@ -0,0 +1,81 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"khalif-alquran/internal/domain"
+	"khalif-alquran/pkg/utils"
+
+)
+
+type MorphologyHandler struct {
+	morphologyUC domain.MorphologyUseCase
+}
+
+func NewMorphologyHandler(morphologyUC domain.MorphologyUseCase) *MorphologyHandler {
+	return &MorphologyHandler{
+		morphologyUC: morphologyUC,
+	}
+}
+
+// GetRoots godoc
+// @Summary      Get Root Frequencies
+// @Description  List every triliteral root in the Quran with its number of occurrences and distinct lemmas, most frequent first
+// @Tags         Morphology
+// @Accept       json
+// @Produce      json
+// @Param        riwayah  query    string  false  "Qira'at riwayah; morphology is only available for hafs"
+// @Success      200  {object}  utils.APIResponse
+// @Failure      400  {object}  utils.APIResponse
+// @Failure      500  {object}  utils.APIResponse
+// @Router       /morphology/roots [get]
+func (h *MorphologyHandler) GetRoots(c *gin.Context) {
+	roots, err := h.morphologyUC.GetRoots(c.Request.Context(), riwayahParam(c))
+	if err != nil {
+		if riwayahError(c, err) {
+			return
+		}
+		utils.ErrorResponse(c, http.StatusInternalServerError, "Failed to fetch roots: "+err.Error())
+		return
+	}
+
+	utils.SuccessResponseWithMeta(c, http.StatusOK, roots, gin.H{
+		"total_roots": len(roots),
+	})
+}
+
+// GetRoot godoc
+// @Summary      Get Root Occurrences
+// @Description  List every word derived from a root with its ayah reference, form, lemma and part of speech
+// @Tags         Morphology
+// @Accept       json
+// @Produce      json
+// @Param        root  path      string  true  "Root in Arabic letters (كتب) or Buckwalter (ktb)"
+// @Param        riwayah  query    string  false  "Qira'at riwayah; morphology is only available for hafs"
+// @Success      200  {object}  utils.APIResponse
+// @Failure      400  {object}  utils.APIResponse
+// @Failure      404  {object}  utils.APIResponse
+// @Router       /morphology/roots/{root} [get]
+func (h *MorphologyHandler) GetRoot(c *gin.Context) {
+	occurrences, err := h.morphologyUC.GetRoot(c.Request.Context(), c.Param("root"), riwayahParam(c))
+	if err != nil {
+		if riwayahError(c, err) {
+			return
+		}
+		switch err {
+		case domain.ErrInvalidRoot:
+			utils.ErrorResponse(c, http.StatusBadRequest, err.Error())
+		case domain.ErrNotFound:
+			utils.ErrorResponse(c, http.StatusNotFound, "Root not found")
+		default:
+			utils.ErrorResponse(c, http.StatusInternalServerError, "Failed to fetch root: "+err.Error())
+		}
+		return
+	}
+
+	utils.SuccessResponseWithMeta(c, http.StatusOK, occurrences, gin.H{
+		"total_occurrences": len(occurrences),
+	})
+}
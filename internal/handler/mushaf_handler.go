@@ -0,0 +1,122 @@
+package handler
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+
+	"khalif-alquran/internal/domain"
+	"khalif-alquran/pkg/utils"
+
+)
+
+type MushafHandler struct {
+	mushafUC domain.MushafUseCase
+}
+
+func NewMushafHandler(mushafUC domain.MushafUseCase) *MushafHandler {
+	return &MushafHandler{
+		mushafUC: mushafUC,
+	}
+}
+
+// GetLayouts godoc
+// @Summary      Get Mushaf Layouts
+// @Description  List the available printed mushaf layouts (e.g. Madani 15-line) with their page counts
+// @Tags         Mushaf
+// @Accept       json
+// @Produce      json
+// @Success      200  {object}  utils.APIResponse
+// @Failure      500  {object}  utils.APIResponse
+// @Router       /mushaf [get]
+func (h *MushafHandler) GetLayouts(c *gin.Context) {
+	layouts, err := h.mushafUC.GetLayouts(c.Request.Context())
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusInternalServerError, "Failed to fetch mushaf layouts: "+err.Error())
+		return
+	}
+
+	utils.SuccessResponse(c, http.StatusOK, layouts)
+}
+
+// GetPage godoc
+// @Summary      Get Mushaf Page
+// @Description  Get the ayahs on one page of a mushaf layout, with line ranges when the layout has line data
+// @Tags         Mushaf
+// @Accept       json
+// @Produce      json
+// @Param        layout  path      string  true  "Layout code (e.g. madani)"
+// @Param        page    path      int     true  "Page number"
+// @Param        riwayah  query    string  false  "Qira'at riwayah; mushaf layouts are only available for hafs"
+// @Success      200  {object}  utils.APIResponse
+// @Failure      400  {object}  utils.APIResponse
+// @Failure      404  {object}  utils.APIResponse
+// @Router       /mushaf/{layout}/pages/{page} [get]
+func (h *MushafHandler) GetPage(c *gin.Context) {
+	page, err := strconv.Atoi(c.Param("page"))
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, "Invalid page number")
+		return
+	}
+
+	result, err := h.mushafUC.GetPage(c.Request.Context(), c.Param("layout"), page, riwayahParam(c))
+	if err != nil {
+		if riwayahError(c, err) {
+			return
+		}
+		switch err {
+		case domain.ErrInvalidMushafPage:
+			utils.ErrorResponse(c, http.StatusBadRequest, err.Error())
+		case domain.ErrNotFound:
+			utils.ErrorResponse(c, http.StatusNotFound, "Mushaf layout or page not found")
+		default:
+			utils.ErrorResponse(c, http.StatusInternalServerError, "Failed to fetch page: "+err.Error())
+		}
+		return
+	}
+
+	utils.SuccessResponse(c, http.StatusOK, result)
+}
+
+// GetAyahLocation godoc
+// @Summary      Get Page of an Ayah
+// @Description  Find the page (and lines, if available) where an ayah appears in a mushaf layout
+// @Tags         Mushaf
+// @Accept       json
+// @Produce      json
+// @Param        layout  path      string  true  "Layout code (e.g. madani)"
+// @Param        surah   path      int     true  "Surah Number (1-114)"
+// @Param        ayah    path      int     true  "Ayah Number"
+// @Param        riwayah  query    string  false  "Qira'at riwayah; mushaf layouts are only available for hafs"
+// @Success      200  {object}  utils.APIResponse
+// @Failure      400  {object}  utils.APIResponse
+// @Failure      404  {object}  utils.APIResponse
+// @Router       /mushaf/{layout}/surahs/{surah}/ayahs/{ayah} [get]
+func (h *MushafHandler) GetAyahLocation(c *gin.Context) {
+	surah, err := strconv.Atoi(c.Param("surah"))
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, "Invalid surah number")
+		return
+	}
+	ayah, err := strconv.Atoi(c.Param("ayah"))
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, "Invalid ayah number")
+		return
+	}
+
+	location, err := h.mushafUC.GetAyahLocation(c.Request.Context(), c.Param("layout"), surah, ayah, riwayahParam(c))
+	if err != nil {
+		if riwayahError(c, err) {
+			return
+		}
+		if err == domain.ErrNotFound {
+			utils.ErrorResponse(c, http.StatusNotFound, "Mushaf layout or ayah not found")
+			return
+		}
+		utils.ErrorResponse(c, http.StatusInternalServerError, "Failed to locate ayah: "+err.Error())
+		return
+	}
+
+	utils.SuccessResponse(c, http.StatusOK, location)
+}
@@ -1,8 +1,11 @@
 package handler
 
 import (
+	"errors"
+	"fmt"
 	"net/http"
 	"strconv"
+	"strings"
 
 	"github.com/gin-gonic/gin"
 
@@ -23,22 +26,37 @@ func NewQuranHandler(quranUC domain.QuranUseCase) *QuranHandler {
 
 // GetAllSurahs godoc
 // @Summary      Get All Surahs
-// @Description  Get a list of all 114 Surahs (metadata only)
+// @Description  Get a list of all 114 Surahs (metadata only), including how many ayahs are available offline. With a riwayah other than hafs, ayah counts follow that riwayah's numbering
 // @Tags         Quran
 // @Accept       json
 // @Produce      json
+// @Param        riwayah       query     string  false  "Qira'at riwayah: hafs (default), warsh or qalun"
 // @Success      200  {object}  utils.APIResponse
+// @Failure      400  {object}  utils.APIResponse
+// @Failure      404  {object}  utils.APIResponse
 // @Failure      500  {object}  utils.APIResponse
 // @Router       /quran/surahs [get]
 func (h *QuranHandler) GetAllSurahs(c *gin.Context) {
-	surahs, err := h.quranUC.GetAllSurahs(c.Request.Context())
+	surahs, err := h.quranUC.GetAllSurahs(c.Request.Context(), riwayahParam(c))
 	if err != nil {
+		if riwayahError(c, err) {
+			return
+		}
 		utils.ErrorResponse(c, http.StatusInternalServerError, "Failed to fetch surahs: "+err.Error())
 		return
 	}
 
-	// Utils SuccessResponse hanya menerima (ctx, code, data)
-	utils.SuccessResponse(c, http.StatusOK, surahs)
+	complete := 0
+	for _, s := range surahs {
+		if s.IsComplete {
+			complete++
+		}
+	}
+
+	utils.SuccessResponseWithMeta(c, http.StatusOK, surahs, gin.H{
+		"total_surahs":    len(surahs),
+		"complete_surahs": complete,
+	})
 }
 
 // GetSurahDetail godoc
@@ -47,7 +65,14 @@ func (h *QuranHandler) GetAllSurahs(c *gin.Context) {
 // @Tags         Quran
 // @Accept       json
 // @Produce      json
-// @Param        number   path      int  true  "Surah Number (1-114)"
+// @Param        number        path      int     true   "Surah Number (1-114)"
+// @Param        translations  query     string  false  "Comma-separated translation editions, e.g. id.kemenag,en.sahih"
+// @Param        include       query     string  false  "Comma-separated extra data to embed per ayah: words, tajwid (rule annotations as character offsets)"
+// @Param        render        query     string  false  "Render mode: tajwid returns each ayah text pre-split into coloured spans"
+// @Param        script        query     string  false  "Arabic script edition: uthmani (default), imlaei or indopak"
+// @Param        X-Quran-Script  header    string  false  "Preferred script edition, used when ?script= is absent"
+// @Param        riwayah       query     string  false  "Qira'at riwayah: hafs (default), warsh or qalun"
+// @Param        X-Quran-Riwayah  header    string  false  "Preferred riwayah, used when ?riwayah= is absent"
 // @Success      200  {object}  utils.APIResponse
 // @Failure      400  {object}  utils.APIResponse
 // @Failure      404  {object}  utils.APIResponse
@@ -61,8 +86,21 @@ func (h *QuranHandler) GetSurahDetail(c *gin.Context) {
 		return
 	}
 
-	surah, err := h.quranUC.GetSurahDetail(c.Request.Context(), number)
+	opts, err := ayahOptions(c)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	surah, err := h.quranUC.GetSurahDetail(c.Request.Context(), number, opts)
 	if err != nil {
+		if riwayahError(c, err) {
+			return
+		}
+		if errors.Is(err, domain.ErrUnknownTranslation) || err == domain.ErrUnknownScript || err == domain.ErrUnknownRender {
+			utils.ErrorResponse(c, http.StatusBadRequest, err.Error())
+			return
+		}
 		if err == domain.ErrNotFound {
 			utils.ErrorResponse(c, http.StatusNotFound, "Surah not found")
 			return
@@ -74,15 +112,340 @@ func (h *QuranHandler) GetSurahDetail(c *gin.Context) {
 	utils.SuccessResponse(c, http.StatusOK, surah)
 }
 
+// GetAyahDetail godoc
+// @Summary      Get Ayah Detail
+// @Description  Get a single Ayah by Surah number and Ayah number, including its sajdah marker if any. The ayah number follows the selected riwayah's numbering
+// @Tags         Quran
+// @Accept       json
+// @Produce      json
+// @Param        number        path      int     true   "Surah Number (1-114)"
+// @Param        ayah          path      int     true   "Ayah Number"
+// @Param        translations  query     string  false  "Comma-separated translation editions, e.g. id.kemenag,en.sahih"
+// @Param        include       query     string  false  "Comma-separated extra data to embed: words (word-by-word text, transliteration and meaning), tajwid (rule annotations as character offsets)"
+// @Param        render        query     string  false  "Render mode: tajwid returns the ayah text pre-split into coloured spans"
+// @Param        script        query     string  false  "Arabic script edition: uthmani (default), imlaei or indopak"
+// @Param        X-Quran-Script  header    string  false  "Preferred script edition, used when ?script= is absent"
+// @Param        riwayah       query     string  false  "Qira'at riwayah: hafs (default), warsh or qalun"
+// @Param        X-Quran-Riwayah  header    string  false  "Preferred riwayah, used when ?riwayah= is absent"
+// @Success      200  {object}  utils.APIResponse
+// @Failure      400  {object}  utils.APIResponse
+// @Failure      404  {object}  utils.APIResponse
+// @Failure      500  {object}  utils.APIResponse
+// @Router       /quran/surahs/{number}/ayahs/{ayah} [get]
+func (h *QuranHandler) GetAyahDetail(c *gin.Context) {
+	surahNumber, err := strconv.Atoi(c.Param("number"))
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, "Invalid surah number")
+		return
+	}
+	ayahNumber, err := strconv.Atoi(c.Param("ayah"))
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, "Invalid ayah number")
+		return
+	}
+
+	opts, err := ayahOptions(c)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	ayah, err := h.quranUC.GetAyahDetail(c.Request.Context(), surahNumber, ayahNumber, opts)
+	if err != nil {
+		if riwayahError(c, err) {
+			return
+		}
+		if errors.Is(err, domain.ErrUnknownTranslation) || err == domain.ErrUnknownScript || err == domain.ErrUnknownRender ||
+			err == domain.ErrInvalidSurahNumber || err == domain.ErrInvalidAyahNumber {
+			utils.ErrorResponse(c, http.StatusBadRequest, err.Error())
+			return
+		}
+		if err == domain.ErrNotFound {
+			utils.ErrorResponse(c, http.StatusNotFound, "Ayah not found")
+			return
+		}
+		utils.ErrorResponse(c, http.StatusInternalServerError, "Failed to fetch ayah: "+err.Error())
+		return
+	}
+
+	utils.SuccessResponse(c, http.StatusOK, ayah)
+}
+
+// GetSurahWords godoc
+// @Summary      Get Word-by-Word Data of a Surah
+// @Description  Get every word of a Surah in reading order with its Uthmani text, transliteration and Indonesian/English meaning
+// @Tags         Quran
+// @Accept       json
+// @Produce      json
+// @Param        number  path      int  true  "Surah Number (1-114)"
+// @Param        riwayah  query    string  false  "Qira'at riwayah; word-by-word data is only available for hafs"
+// @Success      200  {object}  utils.APIResponse
+// @Failure      400  {object}  utils.APIResponse
+// @Failure      404  {object}  utils.APIResponse
+// @Failure      500  {object}  utils.APIResponse
+// @Router       /quran/surahs/{number}/words [get]
+func (h *QuranHandler) GetSurahWords(c *gin.Context) {
+	number, err := strconv.Atoi(c.Param("number"))
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, "Invalid surah number")
+		return
+	}
+
+	words, err := h.quranUC.GetSurahWords(c.Request.Context(), number, riwayahParam(c))
+	if err != nil {
+		if riwayahError(c, err) {
+			return
+		}
+		if err == domain.ErrInvalidSurahNumber {
+			utils.ErrorResponse(c, http.StatusBadRequest, err.Error())
+			return
+		}
+		utils.ErrorResponse(c, http.StatusInternalServerError, "Failed to fetch words: "+err.Error())
+		return
+	}
+
+	utils.SuccessResponseWithMeta(c, http.StatusOK, words, gin.H{
+		"total_words": len(words),
+	})
+}
+
+// GetAyahByGlobalNumber godoc
+// @Summary      Get Ayah by Global Number
+// @Description  Get a single Ayah by its position in the whole Quran (1-6236 for hafs), e.g. for "jump to ayah N"
+// @Tags         Quran
+// @Accept       json
+// @Produce      json
+// @Param        global_number   path      int  true  "Global Ayah Number (1-6236 for hafs)"
+// @Param        riwayah       query     string  false  "Qira'at riwayah: hafs (default), warsh or qalun"
+// @Success      200  {object}  utils.APIResponse
+// @Failure      400  {object}  utils.APIResponse
+// @Failure      404  {object}  utils.APIResponse
+// @Failure      500  {object}  utils.APIResponse
+// @Router       /quran/ayahs/{global_number} [get]
+func (h *QuranHandler) GetAyahByGlobalNumber(c *gin.Context) {
+	number, err := strconv.Atoi(c.Param("global_number"))
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, "Invalid global ayah number")
+		return
+	}
+
+	ayah, err := h.quranUC.GetAyahByGlobalNumber(c.Request.Context(), number, riwayahParam(c))
+	if err != nil {
+		if riwayahError(c, err) {
+			return
+		}
+		switch err {
+		case domain.ErrInvalidGlobalAyah:
+			utils.ErrorResponse(c, http.StatusBadRequest, err.Error())
+		case domain.ErrNotFound:
+			utils.ErrorResponse(c, http.StatusNotFound, "Ayah not found")
+		default:
+			utils.ErrorResponse(c, http.StatusInternalServerError, "Failed to fetch ayah: "+err.Error())
+		}
+		return
+	}
+
+	utils.SuccessResponse(c, http.StatusOK, ayah)
+}
+
+// GetJuz godoc
+// @Summary      Get Juz
+// @Description  Get the ayah range and all Ayahs of one juz
+// @Tags         Quran
+// @Accept       json
+// @Produce      json
+// @Param        number   path      int  true  "Juz Number (1-30)"
+// @Param        riwayah       query     string  false  "Qira'at riwayah: hafs (default), warsh or qalun"
+// @Success      200  {object}  utils.APIResponse
+// @Failure      400  {object}  utils.APIResponse
+// @Failure      404  {object}  utils.APIResponse
+// @Failure      500  {object}  utils.APIResponse
+// @Router       /quran/juz/{number} [get]
+func (h *QuranHandler) GetJuz(c *gin.Context) {
+	h.getDivision(c, domain.DivisionJuz)
+}
+
+// GetHizb godoc
+// @Summary      Get Hizb
+// @Description  Get the ayah range and all Ayahs of one hizb (half of a juz)
+// @Tags         Quran
+// @Accept       json
+// @Produce      json
+// @Param        number   path      int  true  "Hizb Number (1-60)"
+// @Param        riwayah       query     string  false  "Qira'at riwayah: hafs (default), warsh or qalun"
+// @Success      200  {object}  utils.APIResponse
+// @Failure      400  {object}  utils.APIResponse
+// @Failure      404  {object}  utils.APIResponse
+// @Failure      500  {object}  utils.APIResponse
+// @Router       /quran/hizb/{number} [get]
+func (h *QuranHandler) GetHizb(c *gin.Context) {
+	h.getDivision(c, domain.DivisionHizb)
+}
+
+// GetRub godoc
+// @Summary      Get Rub' al-Hizb
+// @Description  Get the ayah range and all Ayahs of one rub' al-hizb (quarter of a hizb)
+// @Tags         Quran
+// @Accept       json
+// @Produce      json
+// @Param        number   path      int  true  "Rub Number (1-240)"
+// @Param        riwayah       query     string  false  "Qira'at riwayah: hafs (default), warsh or qalun"
+// @Success      200  {object}  utils.APIResponse
+// @Failure      400  {object}  utils.APIResponse
+// @Failure      404  {object}  utils.APIResponse
+// @Failure      500  {object}  utils.APIResponse
+// @Router       /quran/rub/{number} [get]
+func (h *QuranHandler) GetRub(c *gin.Context) {
+	h.getDivision(c, domain.DivisionRub)
+}
+
+// GetManzil godoc
+// @Summary      Get Manzil
+// @Description  Get the ayah range and all Ayahs of one manzil (seventh of the Quran)
+// @Tags         Quran
+// @Accept       json
+// @Produce      json
+// @Param        number   path      int  true  "Manzil Number (1-7)"
+// @Param        riwayah       query     string  false  "Qira'at riwayah: hafs (default), warsh or qalun"
+// @Success      200  {object}  utils.APIResponse
+// @Failure      400  {object}  utils.APIResponse
+// @Failure      404  {object}  utils.APIResponse
+// @Failure      500  {object}  utils.APIResponse
+// @Router       /quran/manzil/{number} [get]
+func (h *QuranHandler) GetManzil(c *gin.Context) {
+	h.getDivision(c, domain.DivisionManzil)
+}
+
+// GetRuku godoc
+// @Summary      Get Ruku'
+// @Description  Get the ayah range and all Ayahs of one ruku'
+// @Tags         Quran
+// @Accept       json
+// @Produce      json
+// @Param        number   path      int  true  "Ruku Number (1-558)"
+// @Param        riwayah       query     string  false  "Qira'at riwayah: hafs (default), warsh or qalun"
+// @Success      200  {object}  utils.APIResponse
+// @Failure      400  {object}  utils.APIResponse
+// @Failure      404  {object}  utils.APIResponse
+// @Failure      500  {object}  utils.APIResponse
+// @Router       /quran/ruku/{number} [get]
+func (h *QuranHandler) GetRuku(c *gin.Context) {
+	h.getDivision(c, domain.DivisionRuku)
+}
+
+func (h *QuranHandler) getDivision(c *gin.Context, divisionType string) {
+	number, err := strconv.Atoi(c.Param("number"))
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, "Invalid "+divisionType+" number")
+		return
+	}
+
+	division, err := h.quranUC.GetDivision(c.Request.Context(), divisionType, number, riwayahParam(c))
+	if err != nil {
+		if riwayahError(c, err) {
+			return
+		}
+		switch err {
+		case domain.ErrInvalidDivision:
+			utils.ErrorResponse(c, http.StatusBadRequest, "Invalid "+divisionType+" number")
+		case domain.ErrNotFound:
+			utils.ErrorResponse(c, http.StatusNotFound, "Division not found")
+		default:
+			utils.ErrorResponse(c, http.StatusInternalServerError, "Failed to fetch "+divisionType+": "+err.Error())
+		}
+		return
+	}
+
+	utils.SuccessResponse(c, http.StatusOK, division)
+}
+
+// GetSajdahs godoc
+// @Summary      Get Sajdah Verses
+// @Description  List the 15 sajdah tilawah verses with their type and classification per madhhab
+// @Tags         Quran
+// @Accept       json
+// @Produce      json
+// @Param        riwayah       query     string  false  "Qira'at riwayah: hafs (default), warsh or qalun"
+// @Success      200  {object}  utils.APIResponse
+// @Failure      400  {object}  utils.APIResponse
+// @Failure      404  {object}  utils.APIResponse
+// @Failure      500  {object}  utils.APIResponse
+// @Router       /quran/sajdahs [get]
+func (h *QuranHandler) GetSajdahs(c *gin.Context) {
+	sajdahs, err := h.quranUC.GetSajdahs(c.Request.Context(), riwayahParam(c))
+	if err != nil {
+		if riwayahError(c, err) {
+			return
+		}
+		utils.ErrorResponse(c, http.StatusInternalServerError, "Failed to fetch sajdahs: "+err.Error())
+		return
+	}
+
+	utils.SuccessResponseWithMeta(c, http.StatusOK, sajdahs, gin.H{
+		"total_sajdahs": len(sajdahs),
+	})
+}
+
+// GetTranslations godoc
+// @Summary      Get Translation Editions
+// @Description  List the translation editions (language, translator, source, license) usable in ?translations=
+// @Tags         Quran
+// @Accept       json
+// @Produce      json
+// @Success      200  {object}  utils.APIResponse
+// @Failure      500  {object}  utils.APIResponse
+// @Router       /quran/translations [get]
+func (h *QuranHandler) GetTranslations(c *gin.Context) {
+	translations, err := h.quranUC.GetTranslations(c.Request.Context())
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusInternalServerError, "Failed to fetch translations: "+err.Error())
+		return
+	}
+
+	utils.SuccessResponseWithMeta(c, http.StatusOK, translations, gin.H{
+		"default_edition": domain.DefaultTranslation,
+	})
+}
+
+// GetRiwayat godoc
+// @Summary      Get Qira'at Riwayat
+// @Description  List the riwayat usable in ?riwayah= with their ayah numbering system and total ayah count. Warsh and Qalun are listed once their data has been imported
+// @Tags         Quran
+// @Accept       json
+// @Produce      json
+// @Success      200  {object}  utils.APIResponse
+// @Failure      500  {object}  utils.APIResponse
+// @Router       /quran/riwayat [get]
+func (h *QuranHandler) GetRiwayat(c *gin.Context) {
+	riwayat, err := h.quranUC.GetRiwayat(c.Request.Context())
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusInternalServerError, "Failed to fetch riwayat: "+err.Error())
+		return
+	}
+
+	utils.SuccessResponseWithMeta(c, http.StatusOK, riwayat, gin.H{
+		"default_riwayah": domain.DefaultRiwayah,
+	})
+}
+
 // Search godoc
 // @Summary      Search Quran
-// @Description  Search for Surah names or Ayah texts/translations
+// @Description  Search Surah names (Arabic, latin, English, Indonesian and common spellings, tolerant of typos such as "al baqoroh" or "yaasin"), and Ayah translations (every edition), latin transliteration and tafsir with Postgres full-text search. Ayahs are ranked by relevance and carry a highlighted snippet. Use "quotes" for phrases and a trailing * for prefixes. Arabic queries match the Arabic text regardless of harakat and alef/hamza forms, with match offsets into the vocalized text. When the query is an ayah reference such as 2:255 or QS. Yasin 1-12, the cited ayahs are returned in the reference group
 // @Tags         Quran
 // @Accept       json
 // @Produce      json
-// @Param        q    query     string  true  "Search Query"
+// @Param        q    query     string  true  "Search query; wrap words in double quotes for a phrase, end a word with * for a prefix match"
+// @Param        surah_from    query     int     false  "Only search from this Surah number (1-114)"
+// @Param        surah_to      query     int     false  "Only search up to this Surah number (1-114)"
+// @Param        juz           query     int     false  "Only search Ayahs in this juz (1-30)"
+// @Param        revelation    query     string  false  "Only search meccan (makkiyah) or medinan (madaniyah) Surahs"
+// @Param        fields        query     string  false  "Comma-separated Ayah fields to search: translation, latin, arabic, tafsir (default all)"
+// @Param        limit         query     int     false  "Ayahs per page (default 20, max 100)"
+// @Param        cursor        query     string  false  "meta.next_cursor of the previous page"
+// @Param        riwayah       query     string  false  "Qira'at riwayah: hafs (default), warsh or qalun"
 // @Success      200  {object}  utils.APIResponse
 // @Failure      400  {object}  utils.APIResponse
+// @Failure      404  {object}  utils.APIResponse
 // @Failure      500  {object}  utils.APIResponse
 // @Router       /quran/search [get]
 func (h *QuranHandler) Search(c *gin.Context) {
@@ -92,11 +455,158 @@ func (h *QuranHandler) Search(c *gin.Context) {
 		return
 	}
 
-	result, err := h.quranUC.Search(c.Request.Context(), query)
+	opts, err := searchOptions(c)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	result, err := h.quranUC.Search(c.Request.Context(), query, opts)
 	if err != nil {
+		if riwayahError(c, err) {
+			return
+		}
+		if err == domain.ErrInvalidSearchFilter || err == domain.ErrInvalidCursor {
+			utils.ErrorResponse(c, http.StatusBadRequest, err.Error())
+			return
+		}
 		utils.ErrorResponse(c, http.StatusInternalServerError, "Search failed: "+err.Error())
 		return
 	}
 
+	utils.SuccessResponseWithMeta(c, http.StatusOK, result, gin.H{
+		"total_surahs": len(result.Surahs),
+		"total_ayahs":  result.TotalAyahs,
+		"next_cursor":  result.NextCursor,
+		"has_more":     result.NextCursor != "",
+	})
+}
+
+// ResolveReference godoc
+// @Summary      Resolve Ayah Reference
+// @Description  Parse an ayah citation and return the Surah and the cited Ayahs. Accepts surah:ayah (2:255), ranges and lists (2:1-5,10), Surah names in latin, Indonesian or Arabic (Al-Baqarah 255-257, البقرة ٢٥٥) and the QS. prefix (QS. Al-Baqarah [2]: 255)
+// @Tags         Quran
+// @Accept       json
+// @Produce      json
+// @Param        ref      query     string  true   "Ayah reference, e.g. 2:255, 2:1-5,10 or QS 36:1-12"
+// @Param        riwayah  query     string  false  "Qira'at riwayah: hafs (default), warsh or qalun; ayah numbers follow its numbering"
+// @Success      200  {object}  utils.APIResponse
+// @Failure      400  {object}  utils.APIResponse
+// @Failure      404  {object}  utils.APIResponse
+// @Failure      500  {object}  utils.APIResponse
+// @Router       /quran/resolve [get]
+func (h *QuranHandler) ResolveReference(c *gin.Context) {
+	ref := c.Query("ref")
+	if ref == "" {
+		utils.ErrorResponse(c, http.StatusBadRequest, "Query param 'ref' is required")
+		return
+	}
+
+	result, err := h.quranUC.ResolveReference(c.Request.Context(), ref, riwayahParam(c))
+	if err != nil {
+		if riwayahError(c, err) {
+			return
+		}
+		if err == domain.ErrInvalidReference || err == domain.ErrInvalidSurahNumber || err == domain.ErrInvalidAyahNumber {
+			utils.ErrorResponse(c, http.StatusBadRequest, err.Error())
+			return
+		}
+		if err == domain.ErrNotFound {
+			utils.ErrorResponse(c, http.StatusNotFound, "Surah not found")
+			return
+		}
+		utils.ErrorResponse(c, http.StatusInternalServerError, "Failed to resolve reference: "+err.Error())
+		return
+	}
+
 	utils.SuccessResponse(c, http.StatusOK, result)
+}
+
+// ayahOptions membaca parameter query bersama untuk detail surah dan ayat. Nilai include
+// di luar words dan tajwid ditolak agar salah ketik tidak diam-diam diabaikan.
+func ayahOptions(c *gin.Context) (domain.AyahOptions, error) {
+	var opts domain.AyahOptions
+	for _, code := range strings.Split(c.Query("translations"), ",") {
+		if code = strings.TrimSpace(code); code != "" {
+			opts.Translations = append(opts.Translations, code)
+		}
+	}
+	for _, part := range strings.Split(c.Query("include"), ",") {
+		switch strings.TrimSpace(part) {
+		case "":
+		case "words":
+			opts.Words = true
+		case "tajwid":
+			opts.Tajwid = true
+		default:
+			return opts, domain.ErrInvalidInclude
+		}
+	}
+	opts.Render = c.Query("render")
+
+	// ?script= menang atas preferensi yang disimpan klien di header
+	opts.Script = c.Query("script")
+	if opts.Script == "" {
+		opts.Script = c.GetHeader("X-Quran-Script")
+	}
+	opts.Riwayah = riwayahParam(c)
+	return opts, nil
+}
+
+// searchOptions membaca filter dan paginasi pencarian. Nilainya divalidasi di usecase,
+// di sini hanya memastikan parameter angka memang angka.
+func searchOptions(c *gin.Context) (domain.SearchOptions, error) {
+	opts := domain.SearchOptions{
+		RevelationType: c.Query("revelation"),
+		Cursor:         c.Query("cursor"),
+		Riwayah:        riwayahParam(c),
+	}
+	for _, field := range strings.Split(c.Query("fields"), ",") {
+		if field = strings.TrimSpace(field); field != "" {
+			opts.Fields = append(opts.Fields, field)
+		}
+	}
+
+	numbers := []struct {
+		param string
+		value *int
+	}{
+		{"surah_from", &opts.SurahFrom},
+		{"surah_to", &opts.SurahTo},
+		{"juz", &opts.Juz},
+		{"limit", &opts.Limit},
+	}
+	for _, n := range numbers {
+		raw := c.Query(n.param)
+		if raw == "" {
+			continue
+		}
+		value, err := strconv.Atoi(raw)
+		if err != nil {
+			return opts, fmt.Errorf("query param '%s' must be a number", n.param)
+		}
+		*n.value = value
+	}
+	return opts, nil
+}
+
+// riwayahParam membaca ?riwayah=, atau preferensi yang disimpan klien di header X-Quran-Riwayah
+func riwayahParam(c *gin.Context) string {
+	if riwayah := c.Query("riwayah"); riwayah != "" {
+		return riwayah
+	}
+	return c.GetHeader("X-Quran-Riwayah")
+}
+
+// riwayahError menulis response untuk error pemilihan riwayat, bernilai true jika err sudah ditangani
+func riwayahError(c *gin.Context, err error) bool {
+	switch err {
+	case domain.ErrUnknownRiwayah, domain.ErrRiwayahUnsupported:
+		utils.ErrorResponse(c, http.StatusBadRequest, err.Error())
+	case domain.ErrRiwayahUnavailable:
+		utils.ErrorResponse(c, http.StatusNotFound, err.Error())
+	default:
+		return false
+	}
+	return true
 }
\ No newline at end of file
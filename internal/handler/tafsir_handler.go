@@ -0,0 +1,86 @@
+package handler
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+
+	"khalif-alquran/internal/domain"
+	"khalif-alquran/pkg/utils"
+
+)
+
+type TafsirHandler struct {
+	tafsirUC domain.TafsirUseCase
+}
+
+func NewTafsirHandler(tafsirUC domain.TafsirUseCase) *TafsirHandler {
+	return &TafsirHandler{
+		tafsirUC: tafsirUC,
+	}
+}
+
+// GetEditions godoc
+// @Summary      Get Tafsir Editions
+// @Description  List the available tafsir editions with language, author, form (short/long) and license
+// @Tags         Tafsir
+// @Accept       json
+// @Produce      json
+// @Success      200  {object}  utils.APIResponse
+// @Failure      500  {object}  utils.APIResponse
+// @Router       /tafsir [get]
+func (h *TafsirHandler) GetEditions(c *gin.Context) {
+	editions, err := h.tafsirUC.GetEditions(c.Request.Context())
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusInternalServerError, "Failed to fetch tafsir editions: "+err.Error())
+		return
+	}
+
+	utils.SuccessResponse(c, http.StatusOK, editions)
+}
+
+// GetEntry godoc
+// @Summary      Get Tafsir of an Ayah
+// @Description  Get the tafsir entry covering an ayah. Entries may span several ayahs; from_ayah and to_ayah give the covered range
+// @Tags         Tafsir
+// @Accept       json
+// @Produce      json
+// @Param        edition  path      string  true  "Tafsir edition code"
+// @Param        surah    path      int     true  "Surah Number (1-114)"
+// @Param        ayah     path      int     true  "Ayah Number"
+// @Param        riwayah  query     string  false  "Qira'at riwayah; tafsir is only available for hafs"
+// @Success      200  {object}  utils.APIResponse
+// @Failure      400  {object}  utils.APIResponse
+// @Failure      404  {object}  utils.APIResponse
+// @Router       /tafsir/{edition}/{surah}/{ayah} [get]
+func (h *TafsirHandler) GetEntry(c *gin.Context) {
+	surah, err := strconv.Atoi(c.Param("surah"))
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, "Invalid surah number")
+		return
+	}
+	ayah, err := strconv.Atoi(c.Param("ayah"))
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, "Invalid ayah number")
+		return
+	}
+
+	entry, err := h.tafsirUC.GetEntry(c.Request.Context(), c.Param("edition"), surah, ayah, riwayahParam(c))
+	if err != nil {
+		if riwayahError(c, err) {
+			return
+		}
+		switch err {
+		case domain.ErrInvalidSurahNumber, domain.ErrInvalidAyahNumber:
+			utils.ErrorResponse(c, http.StatusBadRequest, err.Error())
+		case domain.ErrNotFound:
+			utils.ErrorResponse(c, http.StatusNotFound, "Tafsir edition or entry not found")
+		default:
+			utils.ErrorResponse(c, http.StatusInternalServerError, "Failed to fetch tafsir: "+err.Error())
+		}
+		return
+	}
+
+	utils.SuccessResponse(c, http.StatusOK, entry)
+}
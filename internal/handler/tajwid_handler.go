@@ -0,0 +1,42 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"khalif-alquran/internal/domain"
+	"khalif-alquran/pkg/utils"
+
+)
+
+type TajwidHandler struct {
+	tajwidUC domain.TajwidUseCase
+}
+
+func NewTajwidHandler(tajwidUC domain.TajwidUseCase) *TajwidHandler {
+	return &TajwidHandler{
+		tajwidUC: tajwidUC,
+	}
+}
+
+// GetRules godoc
+// @Summary      Get Tajwid Rules
+// @Description  List the tajwid rule catalog with Indonesian and English display names, category and suggested colour. Codes match the rule field of ayah tajwid annotations and spans
+// @Tags         Tajwid
+// @Accept       json
+// @Produce      json
+// @Success      200  {object}  utils.APIResponse
+// @Failure      500  {object}  utils.APIResponse
+// @Router       /tajwid/rules [get]
+func (h *TajwidHandler) GetRules(c *gin.Context) {
+	rules, err := h.tajwidUC.GetRules(c.Request.Context())
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusInternalServerError, "Failed to fetch tajwid rules: "+err.Error())
+		return
+	}
+
+	utils.SuccessResponseWithMeta(c, http.StatusOK, rules, gin.H{
+		"total_rules": len(rules),
+	})
+}
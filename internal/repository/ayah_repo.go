@@ -7,6 +7,7 @@ import (
 	"gorm.io/gorm"
 
 	"khalif-alquran/internal/domain"
+	"khalif-alquran/pkg/utils"
 
 )
 
@@ -38,6 +39,7 @@ func (r *AyahRepository) GetSpecificAyah(ctx context.Context, surahNumber, ayahN
 	// Menggunakan Join untuk mencari berdasarkan Nomor Surat (bukan ID) dan Nomor Ayat
 	err := r.db.WithContext(ctx).
 		Joins("JOIN surahs ON surahs.number = ayahs.surah_id"). // Asumsi surah_id di table ayahs merujuk ke surahs.number
+		Preload("Sajdah").
 		Where("surahs.number = ? AND ayahs.number = ?", surahNumber, ayahNumber).
 		First(&ayah).Error
 
@@ -50,19 +52,168 @@ func (r *AyahRepository) GetSpecificAyah(ctx context.Context, surahNumber, ayahN
 	return &ayah, nil
 }
 
-func (r *AyahRepository) Search(ctx context.Context, query string) ([]domain.Ayah, error) {
-	var ayahs []domain.Ayah
-	searchQuery := "%" + query + "%"
+func (r *AyahRepository) GetByGlobalNumber(ctx context.Context, numberInQuran int) (*domain.Ayah, error) {
+	var ayah domain.Ayah
+	err := r.db.WithContext(ctx).
+		Preload("Sajdah").
+		Where("number_in_quran = ?", numberInQuran).
+		First(&ayah).Error
 
-	// Preload Surah agar frontend tahu ini ayat dari surat apa
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, domain.ErrNotFound
+		}
+		return nil, err
+	}
+	return &ayah, nil
+}
+
+// GetByGlobalRange mengambil ayat berdasarkan rentang number_in_quran (isi juz, hizb, dst)
+func (r *AyahRepository) GetByGlobalRange(ctx context.Context, first, last int) ([]domain.Ayah, error) {
+	var ayahs []domain.Ayah
 	err := r.db.WithContext(ctx).
-		Preload("Surah").
-		Where("translation ILIKE ? OR text_latin ILIKE ?", searchQuery, searchQuery).
-		Limit(20).
+		Preload("Sajdah").
+		Where("number_in_quran BETWEEN ? AND ?", first, last).
+		Order("number_in_quran ASC").
 		Find(&ayahs).Error
 
 	if err != nil {
 		return nil, err
 	}
 	return ayahs, nil
+}
+
+// Search mencari dengan full-text search Postgres di terjemahan (semua edisi), transliterasi latin
+// dan tafsir. Ayat diurutkan berdasarkan ts_rank dan diberi Highlight dari kecocokan terbaiknya.
+// Mendukung frasa ("rahmat allah") dan awalan (rahm*), lihat toTSQuery.
+// Query berhuruf Arab dinormalisasi (utils.NormalizeArabic) lalu dicari di teks Arab tanpa harakat;
+// Highlight.Matches berisi posisi kecocokan pada text_arabic yang berharakat.
+// Hasil dipaginasi dengan cursor (opts.Cursor, SearchResult.NextCursor) berdasarkan urutan rank dan id ayat.
+func (r *AyahRepository) Search(ctx context.Context, query string, opts domain.SearchOptions) (*domain.SearchResult, error) {
+	result := &domain.SearchResult{Ayahs: []domain.Ayah{}}
+
+	var afterRank float64
+	var afterID uint
+	if opts.Cursor != "" {
+		var err error
+		if afterRank, afterID, err = decodeSearchCursor(opts.Cursor); err != nil {
+			return nil, err
+		}
+	}
+
+	arabic := utils.HasArabic(query)
+	tsquery := toTSQuery(query, false)
+	if arabic {
+		tsquery = toTSQuery(utils.NormalizeArabic(query), true)
+	}
+	sources := searchSourcesFor(arabic, opts.Fields)
+	if tsquery == "" || len(sources) == 0 {
+		return result, nil
+	}
+
+	var hits []struct {
+		Total   int
+		AyahID  uint
+		Field   string
+		Edition string
+		Rank    float64
+		Snippet string
+	}
+	// Satu baris lebih dari limit untuk mengetahui apakah masih ada halaman berikutnya
+	err := r.db.WithContext(ctx).
+		Raw(searchSQL(sources, arabic), map[string]interface{}{
+			"query":      tsquery,
+			"limit":      opts.Limit + 1,
+			"surah_from": opts.SurahFrom,
+			"surah_to":   opts.SurahTo,
+			"juz":        opts.Juz,
+			"revelation": opts.RevelationType,
+			"after_rank": afterRank,
+			"after_id":   afterID,
+		}).
+		Scan(&hits).Error
+	if err != nil {
+		return nil, err
+	}
+	if len(hits) > 0 {
+		result.TotalAyahs = hits[0].Total
+	}
+	if len(hits) > opts.Limit {
+		hits = hits[:opts.Limit]
+		last := hits[len(hits)-1]
+		result.NextCursor = encodeSearchCursor(last.Rank, last.AyahID)
+	}
+	if len(hits) == 0 || hits[0].AyahID == 0 {
+		return result, nil
+	}
+
+	ids := make([]uint, len(hits))
+	for i, hit := range hits {
+		ids[i] = hit.AyahID
+	}
+
+	var rows []domain.Ayah
+	// Preload Surah agar frontend tahu ini ayat dari surat apa
+	err = r.db.WithContext(ctx).
+		Preload("Surah", withCompleteness).
+		Where("id IN ?", ids).
+		Find(&rows).Error
+	if err != nil {
+		return nil, err
+	}
+
+	byID := make(map[uint]domain.Ayah, len(rows))
+	for _, ayah := range rows {
+		byID[ayah.ID] = ayah
+	}
+
+	for _, hit := range hits {
+		ayah, ok := byID[hit.AyahID]
+		if !ok {
+			continue
+		}
+		ayah.Highlight = &domain.SearchHighlight{
+			Field:   hit.Field,
+			Edition: hit.Edition,
+			Snippet: hit.Snippet,
+			Rank:    hit.Rank,
+		}
+		if hit.Field == domain.SearchFieldArabic {
+			for _, m := range utils.FindArabic(ayah.TextArabic, query) {
+				ayah.Highlight.Matches = append(ayah.Highlight.Matches, domain.TextRange{Start: m[0], End: m[1]})
+			}
+			ayah.Highlight.Snippet = arabicSnippet(ayah.TextArabic, ayah.Highlight.Matches)
+		}
+		result.Ayahs = append(result.Ayahs, ayah)
+	}
+	return result, nil
+}
+
+// GetScriptBySurah mengambil teks satu edisi rasm untuk seluruh ayat dalam surah
+func (r *AyahRepository) GetScriptBySurah(ctx context.Context, script string, surahNumber int) ([]domain.AyahScript, error) {
+	var rows []domain.AyahScript
+	err := r.db.WithContext(ctx).
+		Where("script = ? AND surah_id = ?", script, surahNumber).
+		Order("ayah_number ASC").
+		Find(&rows).Error
+
+	if err != nil {
+		return nil, err
+	}
+	return rows, nil
+}
+
+func (r *AyahRepository) GetScriptByAyah(ctx context.Context, script string, surahNumber, ayahNumber int) (*domain.AyahScript, error) {
+	var row domain.AyahScript
+	err := r.db.WithContext(ctx).
+		Where("script = ? AND surah_id = ? AND ayah_number = ?", script, surahNumber, ayahNumber).
+		First(&row).Error
+
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, domain.ErrNotFound
+		}
+		return nil, err
+	}
+	return &row, nil
 }
\ No newline at end of file
@@ -25,7 +25,7 @@ func (r *BookmarkRepository) GetByUserID(ctx context.Context, userID string) ([]
 	var bookmarks []domain.Bookmark
 
 	err := r.db.WithContext(ctx).
-		Preload("Surah").
+		Preload("Surah", withCompleteness).
 		Where("user_id = ?", userID).
 		Order("created_at DESC").
 		Find(&bookmarks).Error
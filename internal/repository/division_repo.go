@@ -0,0 +1,44 @@
+package repository
+
+import (
+	"context"
+	"errors"
+
+	"gorm.io/gorm"
+
+	"khalif-alquran/internal/domain"
+
+)
+
+type DivisionRepository struct {
+	db *gorm.DB
+}
+
+func NewDivisionRepository(db *gorm.DB) *DivisionRepository {
+	return &DivisionRepository{db: db}
+}
+
+func (r *DivisionRepository) GetByNumber(ctx context.Context, divisionType string, number int) (*domain.Division, error) {
+	var division domain.Division
+	err := r.db.WithContext(ctx).
+		Where("type = ? AND number = ?", divisionType, number).
+		First(&division).Error
+
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, domain.ErrNotFound
+		}
+		return nil, err
+	}
+	return &division, nil
+}
+
+
+func (r *DivisionRepository) GetSajdahs(ctx context.Context) ([]domain.Sajdah, error) {
+	var sajdahs []domain.Sajdah
+	err := r.db.WithContext(ctx).Order("number ASC").Find(&sajdahs).Error
+	if err != nil {
+		return nil, err
+	}
+	return sajdahs, nil
+}
@@ -0,0 +1,51 @@
+package repository
+
+import (
+	"context"
+
+	"gorm.io/gorm"
+
+	"khalif-alquran/internal/domain"
+
+)
+
+type MorphologyRepository struct {
+	db *gorm.DB
+}
+
+func NewMorphologyRepository(db *gorm.DB) *MorphologyRepository {
+	return &MorphologyRepository{db: db}
+}
+
+// GetRootFrequencies menghitung kemunculan setiap akar kata, dari yang paling sering
+func (r *MorphologyRepository) GetRootFrequencies(ctx context.Context) ([]domain.RootFrequency, error) {
+	var roots []domain.RootFrequency
+	err := r.db.WithContext(ctx).
+		Model(&domain.MorphologySegment{}).
+		Select("root, COUNT(*) AS occurrences, COUNT(DISTINCT lemma) AS lemmas").
+		Where("root <> ''").
+		Group("root").
+		Order("occurrences DESC, root ASC").
+		Scan(&roots).Error
+	if err != nil {
+		return nil, err
+	}
+	return roots, nil
+}
+
+// GetRootOccurrences mengambil setiap kata dengan akar tersebut, urut sesuai mushaf.
+// Teks Uthmani per kata diambil dari tabel words jika sudah tersedia.
+func (r *MorphologyRepository) GetRootOccurrences(ctx context.Context, root string) ([]domain.RootOccurrence, error) {
+	var occurrences []domain.RootOccurrence
+	err := r.db.WithContext(ctx).
+		Table("morphology_segments AS m").
+		Select("m.surah_id, m.ayah_number, m.position, m.form, m.lemma, m.tag, COALESCE(w.text_uthmani, '') AS text_uthmani").
+		Joins("LEFT JOIN words w ON w.surah_id = m.surah_id AND w.ayah_number = m.ayah_number AND w.position = m.position").
+		Where("m.root = ?", root).
+		Order("m.surah_id ASC, m.ayah_number ASC, m.position ASC").
+		Scan(&occurrences).Error
+	if err != nil {
+		return nil, err
+	}
+	return occurrences, nil
+}
@@ -0,0 +1,68 @@
+package repository
+
+import (
+	"context"
+	"errors"
+
+	"gorm.io/gorm"
+
+	"khalif-alquran/internal/domain"
+
+)
+
+type MushafRepository struct {
+	db *gorm.DB
+}
+
+func NewMushafRepository(db *gorm.DB) *MushafRepository {
+	return &MushafRepository{db: db}
+}
+
+func (r *MushafRepository) GetLayouts(ctx context.Context) ([]domain.MushafLayout, error) {
+	var layouts []domain.MushafLayout
+	err := r.db.WithContext(ctx).Order("code ASC").Find(&layouts).Error
+	if err != nil {
+		return nil, err
+	}
+	return layouts, nil
+}
+
+func (r *MushafRepository) GetLayout(ctx context.Context, code string) (*domain.MushafLayout, error) {
+	var layout domain.MushafLayout
+	err := r.db.WithContext(ctx).Where("code = ?", code).First(&layout).Error
+
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, domain.ErrNotFound
+		}
+		return nil, err
+	}
+	return &layout, nil
+}
+
+func (r *MushafRepository) GetPage(ctx context.Context, layout string, page int) (*domain.MushafPage, error) {
+	return r.firstPage(ctx, "layout = ? AND page = ?", layout, page)
+}
+
+// GetPageByAyah mencari halaman yang memuat ayat dengan number_in_quran tertentu
+func (r *MushafRepository) GetPageByAyah(ctx context.Context, layout string, numberInQuran int) (*domain.MushafPage, error) {
+	return r.firstPage(ctx, "layout = ? AND ? BETWEEN first_ayah AND last_ayah", layout, numberInQuran)
+}
+
+func (r *MushafRepository) firstPage(ctx context.Context, query string, args ...interface{}) (*domain.MushafPage, error) {
+	var page domain.MushafPage
+	err := r.db.WithContext(ctx).
+		Preload("Lines", func(db *gorm.DB) *gorm.DB {
+			return db.Order("line ASC")
+		}).
+		Where(query, args...).
+		First(&page).Error
+
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, domain.ErrNotFound
+		}
+		return nil, err
+	}
+	return &page, nil
+}
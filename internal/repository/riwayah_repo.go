@@ -0,0 +1,112 @@
+package repository
+
+import (
+	"context"
+	"errors"
+
+	"gorm.io/gorm"
+
+	"khalif-alquran/internal/domain"
+
+)
+
+type RiwayahRepository struct {
+	db *gorm.DB
+}
+
+func NewRiwayahRepository(db *gorm.DB) *RiwayahRepository {
+	return &RiwayahRepository{db: db}
+}
+
+func (r *RiwayahRepository) GetAll(ctx context.Context) ([]domain.Riwayah, error) {
+	var riwayat []domain.Riwayah
+	err := r.db.WithContext(ctx).Order("code ASC").Find(&riwayat).Error
+	if err != nil {
+		return nil, err
+	}
+	return riwayat, nil
+}
+
+func (r *RiwayahRepository) GetByCode(ctx context.Context, code string) (*domain.Riwayah, error) {
+	var riwayah domain.Riwayah
+	err := r.db.WithContext(ctx).Where("code = ?", code).First(&riwayah).Error
+
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, domain.ErrNotFound
+		}
+		return nil, err
+	}
+	return &riwayah, nil
+}
+
+// GetAyahCounts menghitung jumlah ayat per nomor surah menurut penomoran riwayat.
+// Surah yang belum dimuat untuk riwayat tersebut tidak ada di hasil.
+func (r *RiwayahRepository) GetAyahCounts(ctx context.Context, riwayah string) (map[int]int, error) {
+	var rows []struct {
+		SurahID int
+		Total   int
+	}
+	err := r.db.WithContext(ctx).
+		Model(&domain.RiwayahMapping{}).
+		Select("surah_id, COUNT(*) AS total").
+		Where("riwayah = ?", riwayah).
+		Group("surah_id").
+		Scan(&rows).Error
+	if err != nil {
+		return nil, err
+	}
+
+	counts := make(map[int]int, len(rows))
+	for _, row := range rows {
+		counts[row.SurahID] = row.Total
+	}
+	return counts, nil
+}
+
+// GetTextCounts menghitung jumlah ayat per nomor surah yang sudah punya teks riwayat
+func (r *RiwayahRepository) GetTextCounts(ctx context.Context, riwayah string) (map[int]int, error) {
+	var rows []struct {
+		SurahID int
+		Total   int
+	}
+	err := r.db.WithContext(ctx).
+		Model(&domain.RiwayahAyah{}).
+		Select("surah_id, COUNT(*) AS total").
+		Where("riwayah = ? AND text_arabic <> ''", riwayah).
+		Group("surah_id").
+		Scan(&rows).Error
+	if err != nil {
+		return nil, err
+	}
+
+	counts := make(map[int]int, len(rows))
+	for _, row := range rows {
+		counts[row.SurahID] = row.Total
+	}
+	return counts, nil
+}
+
+func (r *RiwayahRepository) GetMappings(ctx context.Context, riwayah string, surahNumbers []int) ([]domain.RiwayahMapping, error) {
+	var mappings []domain.RiwayahMapping
+	err := r.db.WithContext(ctx).
+		Where("riwayah = ? AND surah_id IN ?", riwayah, surahNumbers).
+		Order("surah_id ASC, number ASC").
+		Find(&mappings).Error
+	if err != nil {
+		return nil, err
+	}
+	return mappings, nil
+}
+
+func (r *RiwayahRepository) GetTexts(ctx context.Context, riwayah string, surahNumbers []int) ([]domain.RiwayahAyah, error) {
+	var texts []domain.RiwayahAyah
+	err := r.db.WithContext(ctx).
+		Where("riwayah = ? AND surah_id IN ?", riwayah, surahNumbers).
+		Order("surah_id ASC, number ASC").
+		Find(&texts).Error
+	if err != nil {
+		return nil, err
+	}
+	return texts, nil
+}
@@ -0,0 +1,242 @@
+package repository
+
+import (
+	"encoding/base64"
+	"fmt"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"khalif-alquran/internal/domain"
+
+)
+
+// Opsi ts_headline: kata yang cocok diapit <mark></mark>, maksimal dua potongan per cuplikan
+const searchHeadlineOptions = "StartSel=<mark>, StopSel=</mark>, MaxFragments=2, MaxWords=30, MinWords=10, FragmentDelimiter=\" … \""
+
+// searchSource adalah satu kolom tsvector yang ikut dicari. Kolom dan konfigurasinya harus sama
+// dengan migrasi 014_search agar GIN index terpakai.
+type searchSource struct {
+	field   string // translation | latin | tafsir | arabic
+	config  string // konfigurasi text search Postgres
+	edition string // ekspresi SQL kode edisi
+	from    string // sumber data, harus menyediakan alias a untuk ayahs
+	vector  string
+	body    string
+	where   string
+}
+
+// searchConfigs memetakan prefix kode edisi ke konfigurasi text search, sama dengan CASE di migrasi
+var searchConfigs = []struct {
+	language string
+	config   string
+}{
+	{"id", "indonesian"},
+	{"en", "english"},
+	{"", "simple"},
+}
+
+func searchSources() []searchSource {
+	sources := []searchSource{
+		{domain.SearchFieldTranslation, "indonesian", "'" + domain.DefaultTranslation + "'", "ayahs a", "a.search_translation", "a.translation", ""},
+		{domain.SearchFieldLatin, "simple", "''", "ayahs a", "a.search_latin", "a.text_latin", ""},
+		{domain.SearchFieldTafsir, "indonesian", "''", "ayahs a", "a.search_tafsir", "a.tafsir", ""},
+	}
+
+	for _, c := range searchConfigs {
+		where := fmt.Sprintf("split_part(%%s.edition, '.', 1) = '%s'", c.language)
+		if c.language == "" {
+			where = "split_part(%s.edition, '.', 1) NOT IN ('id', 'en')"
+		}
+		sources = append(sources,
+			searchSource{domain.SearchFieldTranslation, c.config, "t.edition",
+				"ayah_translations t JOIN ayahs a ON a.surah_id = t.surah_id AND a.number = t.ayah_number",
+				"t.search_vector", "t.text", fmt.Sprintf(where, "t")},
+			// Entri tafsir yang mencakup beberapa ayat dikembalikan sebagai ayat pertamanya
+			searchSource{domain.SearchFieldTafsir, c.config, "e.edition",
+				"tafsir_entries e JOIN ayahs a ON a.surah_id = e.surah_id AND a.number = e.from_ayah",
+				"e.search_vector", "e.text", fmt.Sprintf(where, "e")},
+		)
+	}
+	return sources
+}
+
+// arabicSearchSource mencari teks Arab yang sudah dinormalisasi (migrasi 015_arabic_search).
+// Body tetap text_arabic agar cuplikan bisa dibuat dari teks berharakat.
+var arabicSearchSource = searchSource{domain.SearchFieldArabic, "simple", "''", "ayahs a", "a.search_arabic", "a.text_arabic", ""}
+
+// searchSourcesFor memilih sumber yang dicari: teks Arab saja untuk query berhuruf Arab, selain itu
+// terjemahan, latin dan tafsir. Jika fields diisi, hanya sumber dengan field tersebut yang dipakai.
+func searchSourcesFor(arabic bool, fields []string) []searchSource {
+	sources := searchSources()
+	if arabic {
+		sources = []searchSource{arabicSearchSource}
+	}
+	if len(fields) == 0 {
+		return sources
+	}
+
+	wanted := make(map[string]bool, len(fields))
+	for _, f := range fields {
+		wanted[f] = true
+	}
+	var result []searchSource
+	for _, s := range sources {
+		if wanted[s.field] {
+			result = append(result, s)
+		}
+	}
+	return result
+}
+
+// Filter SearchOptions yang diterapkan ke setiap sumber; @juz 0 dan @revelation kosong berarti tanpa filter
+const searchFilterSQL = "a.surah_id BETWEEN @surah_from AND @surah_to AND (@juz = 0 OR a.juz = @juz) AND " +
+	"(@revelation = '' OR a.surah_id IN (SELECT number FROM surahs WHERE revelation_type = @revelation))"
+
+// searchSQL menyusun query pencarian teks penuh: setiap sumber dicari dengan index-nya sendiri,
+// satu ayat diwakili kecocokan dengan ts_rank tertinggi, dan ts_headline hanya dihitung untuk halaman yang diminta.
+// Jika arabic bernilai true, snippet dikosongkan karena ts_headline tidak bisa mencocokkan kata tanpa
+// harakat di teks berharakat (cuplikan dibuat di Go, lihat arabicSnippet).
+// Baris pertama hasil selalu ada dan membawa total; jika halaman kosong, ayah_id-nya 0.
+// Parameter: @query (teks tsquery), @limit, filter di searchFilterSQL, serta @after_rank dan
+// @after_id dari cursor (@after_id 0 untuk halaman pertama).
+func searchSQL(sources []searchSource, arabic bool) string {
+	headline := "ts_headline(config::regconfig, body, to_tsquery(config::regconfig, @query), '" + strings.ReplaceAll(searchHeadlineOptions, "'", "''") + "')"
+	if arabic {
+		headline = "''"
+	}
+
+	var branches []string
+	for _, s := range sources {
+		tsquery := fmt.Sprintf("to_tsquery('%s', @query)", s.config)
+		cond := fmt.Sprintf("%s @@ %s AND %s", s.vector, tsquery, searchFilterSQL)
+		if s.where != "" {
+			cond += " AND " + s.where
+		}
+		branches = append(branches, fmt.Sprintf(
+			"SELECT a.id AS ayah_id, '%s' AS field, %s AS edition, '%s' AS config, %s AS body, ts_rank(%s, %s) AS rank FROM %s WHERE %s",
+			s.field, s.edition, s.config, s.body, s.vector, tsquery, s.from, cond))
+	}
+
+	return `WITH hits AS (
+	` + strings.Join(branches, "\n\tUNION ALL\n\t") + `
+), best AS (
+	SELECT DISTINCT ON (ayah_id) * FROM hits ORDER BY ayah_id, rank DESC
+), counted AS (
+	SELECT COUNT(*) AS total FROM best
+), ranked AS (
+	SELECT * FROM best
+	WHERE @after_id = 0 OR rank < @after_rank OR (rank = @after_rank AND ayah_id > @after_id)
+	ORDER BY rank DESC, ayah_id ASC LIMIT @limit
+)
+SELECT c.total, COALESCE(r.ayah_id, 0) AS ayah_id, COALESCE(r.field, '') AS field, COALESCE(r.edition, '') AS edition,
+	COALESCE(r.rank, 0) AS rank, COALESCE(` + headline + `, '') AS snippet
+FROM counted c LEFT JOIN ranked r ON true
+ORDER BY r.rank DESC, r.ayah_id ASC`
+}
+
+// encodeSearchCursor menyimpan posisi ayat terakhir di satu halaman (ts_rank dan id ayat).
+// Rank ditulis dengan presisi penuh agar perbandingan di halaman berikutnya tepat.
+func encodeSearchCursor(rank float64, ayahID uint) string {
+	raw := strconv.FormatFloat(rank, 'g', -1, 64) + ":" + strconv.FormatUint(uint64(ayahID), 10)
+	return base64.RawURLEncoding.EncodeToString([]byte(raw))
+}
+
+func decodeSearchCursor(cursor string) (float64, uint, error) {
+	raw, err := base64.RawURLEncoding.DecodeString(cursor)
+	if err != nil {
+		return 0, 0, domain.ErrInvalidCursor
+	}
+	rankText, idText, ok := strings.Cut(string(raw), ":")
+	if !ok {
+		return 0, 0, domain.ErrInvalidCursor
+	}
+	rank, err := strconv.ParseFloat(rankText, 64)
+	if err != nil {
+		return 0, 0, domain.ErrInvalidCursor
+	}
+	id, err := strconv.ParseUint(idText, 10, 32)
+	if err != nil || id == 0 {
+		return 0, 0, domain.ErrInvalidCursor
+	}
+	return rank, uint(id), nil
+}
+
+// toTSQuery mengubah input pengguna menjadi teks tsquery. Kata-kata digabung dengan & (semua harus ada),
+// frasa dalam tanda kutip digabung dengan <-> (harus berurutan), dan kata berakhiran * menjadi
+// pencarian awalan (:*). Jika allPrefix bernilai true, setiap kata menjadi pencarian awalan.
+// Karakter selain huruf dan angka dibuang, sehingga pengguna tidak bisa menyusun operator tsquery
+// sendiri. Hasilnya kosong jika tidak ada kata yang bisa dicari.
+func toTSQuery(query string, allPrefix bool) string {
+	var terms, phrase []string
+	var word strings.Builder
+	inPhrase := false
+
+	flush := func(prefix bool) {
+		if word.Len() == 0 {
+			return
+		}
+		lexeme := word.String()
+		word.Reset()
+		if prefix || allPrefix {
+			lexeme += ":*"
+		}
+		if inPhrase {
+			phrase = append(phrase, lexeme)
+		} else {
+			terms = append(terms, lexeme)
+		}
+	}
+	closePhrase := func() {
+		switch len(phrase) {
+		case 0:
+		case 1:
+			terms = append(terms, phrase[0])
+		default:
+			terms = append(terms, "("+strings.Join(phrase, " <-> ")+")")
+		}
+		phrase = nil
+	}
+
+	for _, r := range query {
+		switch {
+		case unicode.IsLetter(r) || unicode.IsDigit(r) || unicode.Is(unicode.Mn, r):
+			word.WriteRune(r)
+		case r == '*':
+			flush(true)
+		case r == '"':
+			flush(false)
+			if inPhrase {
+				closePhrase()
+			}
+			inPhrase = !inPhrase
+		default:
+			flush(false)
+		}
+	}
+	flush(false)
+	closePhrase()
+
+	return strings.Join(terms, " & ")
+}
+
+// arabicSnippet mengapit setiap rentang kecocokan di text dengan <mark></mark>, sama dengan
+// penanda yang dipakai ts_headline. Rentang berupa index rune dan harus terurut tanpa bertumpuk.
+func arabicSnippet(text string, matches []domain.TextRange) string {
+	runes := []rune(text)
+
+	var b strings.Builder
+	pos := 0
+	for _, m := range matches {
+		if m.Start < pos || m.End > len(runes) || m.Start >= m.End {
+			continue
+		}
+		b.WriteString(string(runes[pos:m.Start]))
+		b.WriteString("<mark>")
+		b.WriteString(string(runes[m.Start:m.End]))
+		b.WriteString("</mark>")
+		pos = m.End
+	}
+	b.WriteString(string(runes[pos:]))
+	return b.String()
+}
@@ -7,6 +7,7 @@ import (
 	"gorm.io/gorm"
 
 	"khalif-alquran/internal/domain"
+	"khalif-alquran/pkg/utils"
 
 )
 
@@ -20,9 +21,17 @@ func NewSurahRepository(db *gorm.DB) *SurahRepository {
 	return &SurahRepository{db: db}
 }
 
+// withCompleteness mengisi AvailableAyahs dan IsComplete pada setiap baris surah
+func withCompleteness(db *gorm.DB) *gorm.DB {
+	return db.Select("surahs.*, " +
+		"(SELECT COUNT(*) FROM ayahs WHERE ayahs.surah_id = surahs.number) AS available_ayahs, " +
+		"(SELECT COUNT(*) FROM ayahs WHERE ayahs.surah_id = surahs.number) >= surahs.total_ayahs AS is_complete")
+}
+
 func (r *SurahRepository) GetAll(ctx context.Context) ([]domain.Surah, error) {
 	var surahs []domain.Surah
 	err := r.db.WithContext(ctx).
+		Scopes(withCompleteness).
 		Order("number ASC").
 		Find(&surahs).Error
 	return surahs, err
@@ -32,9 +41,11 @@ func (r *SurahRepository) GetByNumber(ctx context.Context, number int) (*domain.
 	var surah domain.Surah
 
 	err := r.db.WithContext(ctx).
+		Scopes(withCompleteness).
 		Preload("Ayahs", func(db *gorm.DB) *gorm.DB {
 			return db.Order("ayahs.number ASC")
 		}).
+		Preload("Ayahs.Sajdah").
 		Where("number = ?", number).
 		First(&surah).Error
 
@@ -48,14 +59,100 @@ func (r *SurahRepository) GetByNumber(ctx context.Context, number int) (*domain.
 	return &surah, nil
 }
 
-func (r *SurahRepository) Search(ctx context.Context, query string) ([]domain.Surah, error) {
-	var surahs []domain.Surah
-	searchQuery := "%" + query + "%"
+// FindByName mencari satu surah yang nama atau aliasnya sama persis dengan name setelah dinormalisasi
+// (utils.SurahNameKey). ErrNotFound jika tidak ada, atau jika nama tersebut dipakai lebih dari satu surah.
+func (r *SurahRepository) FindByName(ctx context.Context, name string) (*domain.Surah, error) {
+	key := utils.SurahNameKey(name)
+	if key == "" {
+		return nil, domain.ErrNotFound
+	}
 
+	var numbers []int
 	err := r.db.WithContext(ctx).
-		Where("name ILIKE ? OR latin_name ILIKE ? OR english_name ILIKE ?", searchQuery, searchQuery, searchQuery).
-		Order("number ASC").
-		Find(&surahs).Error
+		Model(&domain.SurahAlias{}).
+		Distinct("surah_id").
+		Where("search_key = ?", key).
+		Pluck("surah_id", &numbers).Error
+	if err != nil {
+		return nil, err
+	}
+	if len(numbers) != 1 {
+		return nil, domain.ErrNotFound
+	}
+	return r.GetByNumber(ctx, numbers[0])
+}
 
-	return surahs, err
+// Jumlah maksimum surah yang dikembalikan satu pencarian
+const surahSearchLimit = 10
+
+// surahSearchSQL memberi skor setiap surah dari alias yang paling mirip dengan @key: 1 jika sama persis,
+// selain itu nilai terbesar antara similarity (ejaan mirip) dan word_similarity (query adalah potongan
+// nama, misal "baq"). Kondisi % dan <% memakai ambang pg_trgm dan GIN index di search_key.
+// Fungsi dan operator pg_trgm dirujuk lewat skema public (lihat migrasi 016_surah_aliases),
+// sehingga tidak bergantung pada search_path koneksi.
+// Filter rentang surah dan jenis turunnya mengikuti SearchOptions (@revelation kosong berarti keduanya).
+const surahSearchSQL = `SELECT surah_id, MAX(CASE WHEN search_key = @key THEN 1
+		ELSE GREATEST(public.similarity(search_key, @key), public.word_similarity(@key, search_key)) END) AS score
+FROM surah_aliases
+WHERE (search_key OPERATOR(public.%) @key OR @key OPERATOR(public.<%) search_key OR strpos(search_key, @key) > 0)
+	AND surah_id BETWEEN @surah_from AND @surah_to
+	AND (@revelation = '' OR surah_id IN (SELECT number FROM surahs WHERE revelation_type = @revelation))
+GROUP BY surah_id
+ORDER BY score DESC, surah_id ASC
+LIMIT @limit`
+
+// Search mencari surah berdasarkan nama Arab, latin, Inggris, Indonesia maupun alias di surah_aliases,
+// tanpa memedulikan variasi ejaan (lihat utils.SurahNameKey). Hasil diurutkan dari yang paling mirip.
+func (r *SurahRepository) Search(ctx context.Context, query string, opts domain.SearchOptions) ([]domain.Surah, error) {
+	key := utils.SurahNameKey(query)
+	if key == "" {
+		return []domain.Surah{}, nil
+	}
+
+	var hits []struct {
+		SurahID int
+		Score   float64
+	}
+	err := r.db.WithContext(ctx).
+		Raw(surahSearchSQL, map[string]interface{}{
+			"key":        key,
+			"limit":      surahSearchLimit,
+			"surah_from": opts.SurahFrom,
+			"surah_to":   opts.SurahTo,
+			"revelation": opts.RevelationType,
+		}).
+		Scan(&hits).Error
+	if err != nil {
+		return nil, err
+	}
+	if len(hits) == 0 {
+		return []domain.Surah{}, nil
+	}
+
+	numbers := make([]int, len(hits))
+	for i, hit := range hits {
+		numbers[i] = hit.SurahID
+	}
+
+	var rows []domain.Surah
+	err = r.db.WithContext(ctx).
+		Scopes(withCompleteness).
+		Where("number IN ?", numbers).
+		Find(&rows).Error
+	if err != nil {
+		return nil, err
+	}
+
+	byNumber := make(map[int]domain.Surah, len(rows))
+	for _, surah := range rows {
+		byNumber[surah.Number] = surah
+	}
+
+	surahs := make([]domain.Surah, 0, len(hits))
+	for _, hit := range hits {
+		if surah, ok := byNumber[hit.SurahID]; ok {
+			surahs = append(surahs, surah)
+		}
+	}
+	return surahs, nil
 }
\ No newline at end of file
@@ -0,0 +1,58 @@
+package repository
+
+import (
+	"context"
+	"errors"
+
+	"gorm.io/gorm"
+
+	"khalif-alquran/internal/domain"
+
+)
+
+type TafsirRepository struct {
+	db *gorm.DB
+}
+
+func NewTafsirRepository(db *gorm.DB) *TafsirRepository {
+	return &TafsirRepository{db: db}
+}
+
+func (r *TafsirRepository) GetEditions(ctx context.Context) ([]domain.TafsirEdition, error) {
+	var editions []domain.TafsirEdition
+	err := r.db.WithContext(ctx).Order("language ASC, code ASC").Find(&editions).Error
+	if err != nil {
+		return nil, err
+	}
+	return editions, nil
+}
+
+func (r *TafsirRepository) GetEdition(ctx context.Context, code string) (*domain.TafsirEdition, error) {
+	var edition domain.TafsirEdition
+	err := r.db.WithContext(ctx).Where("code = ?", code).First(&edition).Error
+
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, domain.ErrNotFound
+		}
+		return nil, err
+	}
+	return &edition, nil
+}
+
+// GetCoveringEntry mencari entri yang rentang ayatnya memuat ayat yang diminta
+func (r *TafsirRepository) GetCoveringEntry(ctx context.Context, edition string, surahNumber, ayahNumber int) (*domain.TafsirEntry, error) {
+	var entry domain.TafsirEntry
+	err := r.db.WithContext(ctx).
+		Where("edition = ? AND surah_id = ? AND from_ayah <= ? AND to_ayah >= ?", edition, surahNumber, ayahNumber, ayahNumber).
+		Order("from_ayah DESC").
+		First(&entry).Error
+
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, domain.ErrNotFound
+		}
+		return nil, err
+	}
+	return &entry, nil
+}
@@ -0,0 +1,51 @@
+package repository
+
+import (
+	"context"
+
+	"gorm.io/gorm"
+
+	"khalif-alquran/internal/domain"
+
+)
+
+type TajwidRepository struct {
+	db *gorm.DB
+}
+
+func NewTajwidRepository(db *gorm.DB) *TajwidRepository {
+	return &TajwidRepository{db: db}
+}
+
+func (r *TajwidRepository) GetRules(ctx context.Context) ([]domain.TajwidRuleDefinition, error) {
+	var rules []domain.TajwidRuleDefinition
+	err := r.db.WithContext(ctx).Order("category ASC, code ASC").Find(&rules).Error
+	if err != nil {
+		return nil, err
+	}
+	return rules, nil
+}
+
+func (r *TajwidRepository) GetBySurah(ctx context.Context, script string, surahNumber int) ([]domain.TajwidAnnotation, error) {
+	var annotations []domain.TajwidAnnotation
+	err := r.db.WithContext(ctx).
+		Where("script = ? AND surah_id = ?", script, surahNumber).
+		Order("ayah_number ASC, start_offset ASC, end_offset DESC").
+		Find(&annotations).Error
+	if err != nil {
+		return nil, err
+	}
+	return annotations, nil
+}
+
+func (r *TajwidRepository) GetByAyah(ctx context.Context, script string, surahNumber, ayahNumber int) ([]domain.TajwidAnnotation, error) {
+	var annotations []domain.TajwidAnnotation
+	err := r.db.WithContext(ctx).
+		Where("script = ? AND surah_id = ? AND ayah_number = ?", script, surahNumber, ayahNumber).
+		Order("start_offset ASC, end_offset DESC").
+		Find(&annotations).Error
+	if err != nil {
+		return nil, err
+	}
+	return annotations, nil
+}
@@ -0,0 +1,60 @@
+package repository
+
+import (
+	"context"
+
+	"gorm.io/gorm"
+
+	"khalif-alquran/internal/domain"
+
+)
+
+type TranslationRepository struct {
+	db *gorm.DB
+}
+
+func NewTranslationRepository(db *gorm.DB) *TranslationRepository {
+	return &TranslationRepository{db: db}
+}
+
+func (r *TranslationRepository) GetAll(ctx context.Context) ([]domain.Translation, error) {
+	var translations []domain.Translation
+	err := r.db.WithContext(ctx).Order("language ASC, code ASC").Find(&translations).Error
+	if err != nil {
+		return nil, err
+	}
+	return translations, nil
+}
+
+func (r *TranslationRepository) GetByCodes(ctx context.Context, codes []string) ([]domain.Translation, error) {
+	var translations []domain.Translation
+	err := r.db.WithContext(ctx).Where("code IN ?", codes).Find(&translations).Error
+	if err != nil {
+		return nil, err
+	}
+	return translations, nil
+}
+
+// GetBySurah mengambil seluruh teks satu edisi untuk satu surah
+func (r *TranslationRepository) GetBySurah(ctx context.Context, edition string, surahNumber int) ([]domain.AyahTranslation, error) {
+	var rows []domain.AyahTranslation
+	err := r.db.WithContext(ctx).
+		Where("edition = ? AND surah_id = ?", edition, surahNumber).
+		Order("ayah_number ASC").
+		Find(&rows).Error
+	if err != nil {
+		return nil, err
+	}
+	return rows, nil
+}
+
+func (r *TranslationRepository) GetByAyah(ctx context.Context, editions []string, surahNumber, ayahNumber int) ([]domain.AyahTranslation, error) {
+	var rows []domain.AyahTranslation
+	err := r.db.WithContext(ctx).
+		Where("edition IN ? AND surah_id = ? AND ayah_number = ?", editions, surahNumber, ayahNumber).
+		Find(&rows).Error
+	if err != nil {
+		return nil, err
+	}
+	return rows, nil
+}
@@ -0,0 +1,43 @@
+package repository
+
+import (
+	"context"
+
+	"gorm.io/gorm"
+
+	"khalif-alquran/internal/domain"
+
+)
+
+type WordRepository struct {
+	db *gorm.DB
+}
+
+func NewWordRepository(db *gorm.DB) *WordRepository {
+	return &WordRepository{db: db}
+}
+
+// GetBySurah mengambil kata per kata seluruh ayat dalam satu surah, urut ayat lalu posisi
+func (r *WordRepository) GetBySurah(ctx context.Context, surahNumber int) ([]domain.Word, error) {
+	var words []domain.Word
+	err := r.db.WithContext(ctx).
+		Where("surah_id = ?", surahNumber).
+		Order("ayah_number ASC, position ASC").
+		Find(&words).Error
+	if err != nil {
+		return nil, err
+	}
+	return words, nil
+}
+
+func (r *WordRepository) GetByAyah(ctx context.Context, surahNumber, ayahNumber int) ([]domain.Word, error) {
+	var words []domain.Word
+	err := r.db.WithContext(ctx).
+		Where("surah_id = ? AND ayah_number = ?", surahNumber, ayahNumber).
+		Order("position ASC").
+		Find(&words).Error
+	if err != nil {
+		return nil, err
+	}
+	return words, nil
+}
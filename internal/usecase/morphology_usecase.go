@@ -0,0 +1,125 @@
+package usecase
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+	"unicode"
+
+	"khalif-alquran/internal/domain"
+	"khalif-alquran/pkg/utils"
+
+)
+
+type MorphologyUC struct {
+	morphologyRepo domain.MorphologyRepository
+	redisRepo      domain.RedisRepository
+}
+
+func NewMorphologyUseCase(morphologyRepo domain.MorphologyRepository, redisRepo domain.RedisRepository) *MorphologyUC {
+	return &MorphologyUC{
+		morphologyRepo: morphologyRepo,
+		redisRepo:      redisRepo,
+	}
+}
+
+// GetRoots mengembalikan frekuensi semua akar kata beserta transliterasi Buckwalter-nya.
+// Data morfologi dihitung dari teks Hafs, sehingga riwayat lain ditolak.
+func (uc *MorphologyUC) GetRoots(ctx context.Context, riwayahCode string) ([]domain.RootFrequency, error) {
+	if err := requireHafs(riwayahCode); err != nil {
+		return nil, err
+	}
+
+	cacheKey := domain.CacheKeyMorphologyRoots
+
+	if uc.redisRepo != nil {
+		cachedData, err := uc.redisRepo.Get(ctx, cacheKey)
+		if err == nil && cachedData != "" {
+			var roots []domain.RootFrequency
+			if err := json.Unmarshal([]byte(cachedData), &roots); err == nil {
+				return roots, nil
+			}
+		}
+	}
+
+	roots, err := uc.morphologyRepo.GetRootFrequencies(ctx)
+	if err != nil {
+		return nil, err
+	}
+	for i := range roots {
+		roots[i].Buckwalter = utils.ArabicToBuckwalter(roots[i].Root)
+	}
+
+	if uc.redisRepo != nil {
+		if data, err := json.Marshal(roots); err == nil {
+			_ = uc.redisRepo.Set(ctx, cacheKey, data, 24*time.Hour)
+		}
+	}
+
+	return roots, nil
+}
+
+// GetRoot mengembalikan setiap kemunculan akar kata. Akar boleh ditulis dalam huruf Arab (كتب)
+// atau Buckwalter (ktb) seperti di Quranic Arabic Corpus.
+func (uc *MorphologyUC) GetRoot(ctx context.Context, root, riwayahCode string) ([]domain.RootOccurrence, error) {
+	if err := requireHafs(riwayahCode); err != nil {
+		return nil, err
+	}
+
+	root, err := normalizeRoot(root)
+	if err != nil {
+		return nil, err
+	}
+
+	cacheKey := fmt.Sprintf("%sroot:%s", domain.CacheKeyMorphologyPrefix, root)
+
+	if uc.redisRepo != nil {
+		cachedData, err := uc.redisRepo.Get(ctx, cacheKey)
+		if err == nil && cachedData != "" {
+			var occurrences []domain.RootOccurrence
+			if err := json.Unmarshal([]byte(cachedData), &occurrences); err == nil {
+				return occurrences, nil
+			}
+		}
+	}
+
+	occurrences, err := uc.morphologyRepo.GetRootOccurrences(ctx, root)
+	if err != nil {
+		return nil, err
+	}
+	if len(occurrences) == 0 {
+		return nil, domain.ErrNotFound
+	}
+
+	for i := range occurrences {
+		o := &occurrences[i]
+		o.Reference = fmt.Sprintf("%d:%d", o.SurahID, o.AyahNumber)
+	}
+
+	if uc.redisRepo != nil {
+		if data, err := json.Marshal(occurrences); err == nil {
+			_ = uc.redisRepo.Set(ctx, cacheKey, data, 24*time.Hour)
+		}
+	}
+
+	return occurrences, nil
+}
+
+// normalizeRoot mengubah input Buckwalter ke huruf Arab dan menolak karakter selain huruf Arab
+func normalizeRoot(root string) (string, error) {
+	root = strings.Join(strings.Fields(root), "")
+	if utils.IsBuckwalter(root) {
+		root = utils.BuckwalterToArabic(root)
+	}
+	if root == "" {
+		return "", domain.ErrInvalidRoot
+	}
+	for _, r := range root {
+		if !unicode.Is(unicode.Arabic, r) {
+			return "", domain.ErrInvalidRoot
+		}
+	}
+	return root, nil
+}
@@ -0,0 +1,128 @@
+package usecase
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"khalif-alquran/internal/domain"
+
+)
+
+type MushafUC struct {
+	mushafRepo domain.MushafRepository
+	ayahRepo   domain.AyahRepository
+	redisRepo  domain.RedisRepository
+}
+
+func NewMushafUseCase(mushafRepo domain.MushafRepository, ayahRepo domain.AyahRepository, redisRepo domain.RedisRepository) *MushafUC {
+	return &MushafUC{
+		mushafRepo: mushafRepo,
+		ayahRepo:   ayahRepo,
+		redisRepo:  redisRepo,
+	}
+}
+
+func (uc *MushafUC) GetLayouts(ctx context.Context) ([]domain.MushafLayout, error) {
+	return uc.mushafRepo.GetLayouts(ctx)
+}
+
+// GetPage mengembalikan satu halaman mushaf beserta baris dan ayat-ayatnya. Layout halaman
+// mengikuti mushaf Hafs, sehingga riwayat lain ditolak.
+func (uc *MushafUC) GetPage(ctx context.Context, layout string, page int, riwayahCode string) (*domain.MushafPage, error) {
+	if err := requireHafs(riwayahCode); err != nil {
+		return nil, err
+	}
+	info, err := uc.mushafRepo.GetLayout(ctx, layout)
+	if err != nil {
+		return nil, err
+	}
+	if page < 1 || page > info.TotalPages {
+		return nil, domain.ErrInvalidMushafPage
+	}
+
+	cacheKey := fmt.Sprintf("%s%s:%d", domain.CacheKeyMushafPrefix, layout, page)
+
+	if uc.redisRepo != nil {
+		cachedData, err := uc.redisRepo.Get(ctx, cacheKey)
+		if err == nil && cachedData != "" {
+			var cached domain.MushafPage
+			if err := json.Unmarshal([]byte(cachedData), &cached); err == nil {
+				return &cached, nil
+			}
+		}
+	}
+
+	result, err := uc.mushafRepo.GetPage(ctx, layout, page)
+	if err != nil {
+		return nil, err
+	}
+
+	ayahs, err := uc.ayahRepo.GetByGlobalRange(ctx, result.FirstAyah, result.LastAyah)
+	if err != nil {
+		return nil, err
+	}
+
+	result.Ayahs = make([]domain.PageAyah, 0, len(ayahs))
+	for _, ayah := range ayahs {
+		start, end := lineRange(result.Lines, ayah.NumberInQuran)
+		result.Ayahs = append(result.Ayahs, domain.PageAyah{Ayah: ayah, LineStart: start, LineEnd: end})
+	}
+
+	if uc.redisRepo != nil {
+		if data, err := json.Marshal(result); err == nil {
+			_ = uc.redisRepo.Set(ctx, cacheKey, data, 24*time.Hour)
+		}
+	}
+
+	return result, nil
+}
+
+// GetAyahLocation mencari di halaman (dan baris) mana sebuah ayat berada pada layout tertentu
+func (uc *MushafUC) GetAyahLocation(ctx context.Context, layout string, surahNumber, ayahNumber int, riwayahCode string) (*domain.MushafLocation, error) {
+	if err := requireHafs(riwayahCode); err != nil {
+		return nil, err
+	}
+	if _, err := uc.mushafRepo.GetLayout(ctx, layout); err != nil {
+		return nil, err
+	}
+
+	ayah, err := uc.ayahRepo.GetSpecificAyah(ctx, surahNumber, ayahNumber)
+	if err != nil {
+		return nil, err
+	}
+
+	page, err := uc.mushafRepo.GetPageByAyah(ctx, layout, ayah.NumberInQuran)
+	if err != nil {
+		return nil, err
+	}
+
+	start, end := lineRange(page.Lines, ayah.NumberInQuran)
+	return &domain.MushafLocation{
+		Layout:        layout,
+		Page:          page.Page,
+		SurahNumber:   surahNumber,
+		AyahNumber:    ayahNumber,
+		NumberInQuran: ayah.NumberInQuran,
+		LineStart:     start,
+		LineEnd:       end,
+	}, nil
+}
+
+// lineRange mengembalikan baris pertama dan terakhir yang memuat ayat, 0 jika layout tidak punya data baris
+func lineRange(lines []domain.MushafLine, numberInQuran int) (start, end int) {
+	for _, line := range lines {
+		if line.FirstAyah == nil || line.LastAyah == nil {
+			continue
+		}
+		if numberInQuran < *line.FirstAyah || numberInQuran > *line.LastAyah {
+			continue
+		}
+		if start == 0 {
+			start = line.Line
+		}
+		end = line.Line
+	}
+	return start, end
+}
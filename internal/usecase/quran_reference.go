@@ -0,0 +1,175 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"khalif-alquran/internal/domain"
+
+)
+
+// Awalan "QS" / "Q.S." yang biasa dipakai di kutipan berbahasa Indonesia
+var referencePrefix = regexp.MustCompile(`(?i)^q\.?\s?s\b\.?\s*`)
+
+// referencePattern: surah (nomor atau nama), nomor surah dalam kurung siku yang opsional, pemisah titik dua
+// atau spasi, kata "ayat"/"ayah" yang opsional, lalu daftar ayat seperti "1-5,10"
+var referencePattern = regexp.MustCompile(`(?i)^(.+?)\s*(?:\[(\d{1,3})\])?\s*(?::|\s)\s*(?:(?:ayat|ayah)\s+)?(\d+(?:\s*[-–]\s*\d+)?(?:\s*,\s*\d+(?:\s*[-–]\s*\d+)?)*)$`)
+
+// Angka Arab-Indic (٠-٩) dan Persia (۰-۹) diubah menjadi angka biasa
+var referenceDigits = strings.NewReplacer(
+	"٠", "0", "١", "1", "٢", "2", "٣", "3", "٤", "4", "٥", "5", "٦", "6", "٧", "7", "٨", "8", "٩", "9",
+	"۰", "0", "۱", "1", "۲", "2", "۳", "3", "۴", "4", "۵", "5", "۶", "6", "۷", "7", "۸", "8", "۹", "9",
+)
+
+// ayahReference adalah rujukan yang sudah dibaca tetapi belum dicocokkan ke database.
+// Tepat satu dari surahNumber dan surahName yang terisi.
+type ayahReference struct {
+	surahNumber int
+	surahName   string
+	ranges      []domain.AyahRange
+}
+
+// parseReference membaca rujukan ayat: "2:255", "2:1-5,10", "Al-Baqarah 255-257", "البقرة ٢٥٥",
+// "QS 36:1-12", "QS. Al-Baqarah [2]: 255" atau "Yasin ayat 1-12". Nama surah belum dicocokkan di sini.
+// Mengembalikan ErrInvalidReference jika ref bukan rujukan ayat.
+func parseReference(ref string) (*ayahReference, error) {
+	s := strings.TrimSpace(referenceDigits.Replace(ref))
+	s = referencePrefix.ReplaceAllString(s, "")
+
+	m := referencePattern.FindStringSubmatch(s)
+	if m == nil {
+		return nil, domain.ErrInvalidReference
+	}
+
+	result := &ayahReference{}
+	switch {
+	case m[2] != "":
+		result.surahNumber, _ = strconv.Atoi(m[2])
+	case isDigits(m[1]):
+		result.surahNumber, _ = strconv.Atoi(m[1])
+	default:
+		result.surahName = strings.TrimSpace(m[1])
+	}
+	if result.surahName == "" && (result.surahNumber < 1 || result.surahNumber > 114) {
+		return nil, domain.ErrInvalidSurahNumber
+	}
+
+	for _, item := range strings.Split(m[3], ",") {
+		bounds := strings.FieldsFunc(item, func(r rune) bool { return r == '-' || r == '–' })
+		from, err := strconv.Atoi(strings.TrimSpace(bounds[0]))
+		if err != nil {
+			return nil, domain.ErrInvalidReference
+		}
+		to := from
+		if len(bounds) > 1 {
+			if to, err = strconv.Atoi(strings.TrimSpace(bounds[1])); err != nil {
+				return nil, domain.ErrInvalidReference
+			}
+		}
+		if from < 1 || to < from {
+			return nil, domain.ErrInvalidReference
+		}
+		result.ranges = append(result.ranges, domain.AyahRange{From: from, To: to})
+	}
+	result.ranges = mergeAyahRanges(result.ranges)
+
+	return result, nil
+}
+
+func isDigits(s string) bool {
+	if s == "" {
+		return false
+	}
+	for _, r := range s {
+		if r < '0' || r > '9' {
+			return false
+		}
+	}
+	return true
+}
+
+// mergeAyahRanges mengurutkan rentang dan menggabungkan yang bertumpuk atau bersebelahan
+func mergeAyahRanges(ranges []domain.AyahRange) []domain.AyahRange {
+	sort.Slice(ranges, func(i, j int) bool { return ranges[i].From < ranges[j].From })
+
+	merged := make([]domain.AyahRange, 0, len(ranges))
+	for _, r := range ranges {
+		if n := len(merged); n > 0 && r.From <= merged[n-1].To+1 {
+			if r.To > merged[n-1].To {
+				merged[n-1].To = r.To
+			}
+			continue
+		}
+		merged = append(merged, r)
+	}
+	return merged
+}
+
+// formatReference menulis rujukan dalam bentuk baku, misal "2:1-5,10"
+func formatReference(surah int, ranges []domain.AyahRange) string {
+	parts := make([]string, len(ranges))
+	for i, r := range ranges {
+		parts[i] = strconv.Itoa(r.From)
+		if r.To > r.From {
+			parts[i] += "-" + strconv.Itoa(r.To)
+		}
+	}
+	return fmt.Sprintf("%d:%s", surah, strings.Join(parts, ","))
+}
+
+// ResolveReference membaca rujukan ayat (lihat parseReference) lalu mengembalikan surah dan ayat-ayatnya.
+// Nomor ayat mengikuti penomoran riwayat yang dipilih. Nama surah harus cocok persis dengan nama atau
+// alias setelah dinormalisasi, sehingga kata kunci biasa seperti "zakat 2" tidak dianggap rujukan.
+func (uc *QuranUC) ResolveReference(ctx context.Context, ref, riwayahCode string) (*domain.ResolvedReference, error) {
+	parsed, err := parseReference(ref)
+	if err != nil {
+		return nil, err
+	}
+
+	riwayah, err := uc.resolveRiwayah(ctx, riwayahCode)
+	if err != nil {
+		return nil, err
+	}
+
+	number := parsed.surahNumber
+	if parsed.surahName != "" {
+		surah, err := uc.surahRepo.FindByName(ctx, parsed.surahName)
+		if err != nil {
+			return nil, err
+		}
+		number = surah.Number
+	}
+
+	surah, err := uc.getSurah(ctx, number, riwayah, domain.DefaultScript)
+	if err != nil {
+		return nil, err
+	}
+	if last := parsed.ranges[len(parsed.ranges)-1]; last.To > surah.TotalAyahs {
+		return nil, domain.ErrInvalidAyahNumber
+	}
+
+	result := &domain.ResolvedReference{
+		Query:     ref,
+		Reference: formatReference(number, parsed.ranges),
+		Ranges:    parsed.ranges,
+		Ayahs:     []domain.Ayah{},
+	}
+	for _, ayah := range surah.Ayahs {
+		for _, r := range parsed.ranges {
+			if ayah.Number >= r.From && ayah.Number <= r.To {
+				result.Ayahs = append(result.Ayahs, ayah)
+				break
+			}
+		}
+	}
+
+	info := *surah
+	info.Ayahs = nil
+	result.Surah = &info
+
+	return result, nil
+}
@@ -0,0 +1,388 @@
+package usecase
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"khalif-alquran/internal/domain"
+
+)
+
+// hafsRiwayah adalah entri katalog DefaultRiwayah. Teksnya ada di tabel ayahs, jadi tidak disimpan di tabel riwayahs.
+var hafsRiwayah = domain.Riwayah{
+	Code:       domain.RiwayahHafs,
+	Name:       "Hafs 'an 'Ashim",
+	Qiraah:     "'Ashim",
+	Numbering:  "kufi",
+	TotalAyahs: domain.TotalQuranAyahs,
+}
+
+func (uc *QuranUC) GetRiwayat(ctx context.Context) ([]domain.Riwayah, error) {
+	riwayat, err := uc.riwayahRepo.GetAll(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return append([]domain.Riwayah{hafsRiwayah}, riwayat...), nil
+}
+
+// requireHafs dipakai data yang hanya ada dalam penomoran Hafs (tafsir, halaman mushaf, morfologi):
+// riwayat lain yang dikenal ditolak dengan ErrRiwayahUnsupported, kode asing dengan ErrUnknownRiwayah
+func requireHafs(code string) error {
+	switch code {
+	case "", domain.RiwayahHafs:
+		return nil
+	case domain.RiwayahWarsh, domain.RiwayahQalun:
+		return domain.ErrRiwayahUnsupported
+	default:
+		return domain.ErrUnknownRiwayah
+	}
+}
+
+// resolveRiwayah memvalidasi riwayat dari ?riwayah= atau preferensi user; kosong berarti DefaultRiwayah.
+// Riwayat yang dikenal tapi belum pernah dimuat menghasilkan ErrRiwayahUnavailable.
+func (uc *QuranUC) resolveRiwayah(ctx context.Context, code string) (*domain.Riwayah, error) {
+	switch code {
+	case "", domain.RiwayahHafs:
+		riwayah := hafsRiwayah
+		return &riwayah, nil
+	case domain.RiwayahWarsh, domain.RiwayahQalun:
+		riwayah, err := uc.riwayahRepo.GetByCode(ctx, code)
+		if err == domain.ErrNotFound {
+			return nil, domain.ErrRiwayahUnavailable
+		}
+		return riwayah, err
+	default:
+		return nil, domain.ErrUnknownRiwayah
+	}
+}
+
+// riwayahCounts mengembalikan jumlah ayat per nomor surah menurut penomoran riwayat
+func (uc *QuranUC) riwayahCounts(ctx context.Context, riwayah *domain.Riwayah) (map[int]int, error) {
+	if riwayah.Code == domain.DefaultRiwayah {
+		surahs, err := uc.getAllSurahs(ctx)
+		if err != nil {
+			return nil, err
+		}
+		counts := make(map[int]int, len(surahs))
+		for _, s := range surahs {
+			counts[s.Number] = s.TotalAyahs
+		}
+		return counts, nil
+	}
+
+	cacheKey := fmt.Sprintf("%s%s:counts", domain.CacheKeyRiwayahPrefix, riwayah.Code)
+
+	if uc.redisRepo != nil {
+		cachedData, err := uc.redisRepo.Get(ctx, cacheKey)
+		if err == nil && cachedData != "" {
+			var counts map[int]int
+			if err := json.Unmarshal([]byte(cachedData), &counts); err == nil {
+				return counts, nil
+			}
+		}
+	}
+
+	counts, err := uc.riwayahRepo.GetAyahCounts(ctx, riwayah.Code)
+	if err != nil {
+		return nil, err
+	}
+
+	if uc.redisRepo != nil {
+		if data, err := json.Marshal(counts); err == nil {
+			_ = uc.redisRepo.Set(ctx, cacheKey, data, 24*time.Hour)
+		}
+	}
+
+	return counts, nil
+}
+
+// riwayahOffsets mengembalikan jumlah ayat sebelum tiap surah (untuk number_in_quran).
+// Hasilnya nil jika riwayat belum dimuat lengkap, karena nomor global belum bisa dihitung.
+func riwayahOffsets(riwayah *domain.Riwayah, counts map[int]int) map[int]int {
+	offsets := make(map[int]int, 114)
+	total := 0
+	for number := 1; number <= 114; number++ {
+		offsets[number] = total
+		total += counts[number]
+	}
+	if total != riwayah.TotalAyahs {
+		return nil
+	}
+	return offsets
+}
+
+// validateAyah memastikan nomor surah dan ayat ada dalam penomoran riwayat yang dipilih
+func (uc *QuranUC) validateAyah(ctx context.Context, riwayah *domain.Riwayah, surahNumber, ayahNumber int) error {
+	if surahNumber < 1 || surahNumber > 114 {
+		return domain.ErrInvalidSurahNumber
+	}
+
+	counts, err := uc.riwayahCounts(ctx, riwayah)
+	if err != nil {
+		return err
+	}
+
+	count, ok := counts[surahNumber]
+	if !ok && riwayah.Code != domain.DefaultRiwayah {
+		return domain.ErrRiwayahUnavailable
+	}
+	if ayahNumber < 1 || (ok && ayahNumber > count) {
+		return domain.ErrInvalidAyahNumber
+	}
+	return nil
+}
+
+// toRiwayah mengubah ayat-ayat Hafs menjadi ayat riwayat yang mencakupnya, dengan nomor dan teks riwayat tersebut.
+// Terjemahan, tafsir dan letak (juz/hizb/halaman) diambil dari ayat Hafs; jika satu ayat riwayat mencakup
+// beberapa ayat Hafs, teksnya digabung. Transliterasi, tajwid, audio dan kata per kata dikosongkan karena
+// mengikuti bacaan Hafs, begitu pula Highlight pencarian teks Arab karena posisinya menunjuk ke teks Hafs.
+// Ayat Hafs yang tidak dipetakan (misal basmalah Al-Fatihah pada penomoran Madani) dilewati. Ayat riwayat
+// yang belum punya teks menghasilkan ErrRiwayahUnavailable. Jika satu ayat Hafs dipecah menjadi
+// beberapa ayat riwayat, setiap bagian ditandai HafsPart dan hanya bagian pertama yang membawa terjemahan,
+// tafsir dan asbabun nuzul.
+func (uc *QuranUC) toRiwayah(ctx context.Context, riwayah *domain.Riwayah, ayahs []domain.Ayah) ([]domain.Ayah, error) {
+	if riwayah.Code == domain.DefaultRiwayah || len(ayahs) == 0 {
+		return ayahs, nil
+	}
+
+	byHafs := make(map[[2]int]*domain.Ayah, len(ayahs))
+	seen := make(map[int]bool)
+	var surahs []int
+	for i := range ayahs {
+		number := int(ayahs[i].SurahID)
+		byHafs[[2]int{number, ayahs[i].Number}] = &ayahs[i]
+		if !seen[number] {
+			seen[number] = true
+			surahs = append(surahs, number)
+		}
+	}
+	sort.Ints(surahs)
+
+	mappings, err := uc.riwayahRepo.GetMappings(ctx, riwayah.Code, surahs)
+	if err != nil {
+		return nil, err
+	}
+	loaded := make(map[int]bool, len(surahs))
+	for _, m := range mappings {
+		loaded[int(m.SurahID)] = true
+	}
+	for _, number := range surahs {
+		if !loaded[number] {
+			return nil, domain.ErrRiwayahUnavailable
+		}
+	}
+
+	rows, err := uc.riwayahRepo.GetTexts(ctx, riwayah.Code, surahs)
+	if err != nil {
+		return nil, err
+	}
+	texts := make(map[[2]int]string, len(rows))
+	for _, row := range rows {
+		texts[[2]int{int(row.SurahID), row.Number}] = row.TextArabic
+	}
+
+	counts, err := uc.riwayahCounts(ctx, riwayah)
+	if err != nil {
+		return nil, err
+	}
+	offsets := riwayahOffsets(riwayah, counts)
+
+	// Jumlah ayat riwayat yang mencakup satu ayat Hafs yang sama (lebih dari satu berarti dipecah)
+	splits := make(map[[2]int]int)
+	for _, m := range mappings {
+		if m.HafsFrom == m.HafsTo {
+			splits[[2]int{int(m.SurahID), m.HafsFrom}]++
+		}
+	}
+	parts := make(map[[2]int]int)
+
+	result := make([]domain.Ayah, 0, len(ayahs))
+	for _, m := range mappings {
+		var covered []*domain.Ayah
+		hafsNumbers := make([]int, 0, m.HafsTo-m.HafsFrom+1)
+		for n := m.HafsFrom; n <= m.HafsTo; n++ {
+			hafsNumbers = append(hafsNumbers, n)
+			if ayah, ok := byHafs[[2]int{int(m.SurahID), n}]; ok {
+				covered = append(covered, ayah)
+			}
+		}
+		if len(covered) == 0 {
+			continue
+		}
+
+		ayah := *covered[0]
+		ayah.Number = m.Number
+		ayah.NumberInQuran = 0
+		if offsets != nil {
+			ayah.NumberInQuran = offsets[int(m.SurahID)] + m.Number
+		}
+		text, ok := texts[[2]int{int(m.SurahID), m.Number}]
+		if !ok || text == "" {
+			return nil, domain.ErrRiwayahUnavailable
+		}
+		ayah.TextArabic = text
+		ayah.TextLatin = ""
+		ayah.TajwidInfo = nil
+		ayah.AudioURL = ""
+		ayah.Script = ""
+		ayah.Words = nil
+		ayah.Riwayah = riwayah.Code
+		ayah.HafsNumbers = hafsNumbers
+		if ayah.Highlight != nil && ayah.Highlight.Field == domain.SearchFieldArabic {
+			ayah.Highlight = nil
+		}
+
+		if len(covered) > 1 {
+			var translations, tafsir, asbab []string
+			for _, a := range covered {
+				translations = appendNonEmpty(translations, a.Translation)
+				tafsir = appendNonEmpty(tafsir, a.Tafsir)
+				asbab = appendNonEmpty(asbab, a.AsbabunNuzul)
+				if ayah.Sajdah == nil {
+					ayah.Sajdah = a.Sajdah
+				}
+			}
+			ayah.Translation = strings.Join(translations, " ")
+			ayah.Tafsir = strings.Join(tafsir, "\n\n")
+			ayah.AsbabunNuzul = strings.Join(asbab, "\n\n")
+		}
+
+		if key := [2]int{int(m.SurahID), m.HafsFrom}; m.HafsFrom == m.HafsTo && splits[key] > 1 {
+			parts[key]++
+			ayah.HafsPart = parts[key]
+			if ayah.HafsPart > 1 {
+				ayah.Translation = ""
+				ayah.Tafsir = ""
+				ayah.AsbabunNuzul = ""
+			}
+		}
+
+		result = append(result, ayah)
+	}
+	return result, nil
+}
+
+func appendNonEmpty(list []string, s string) []string {
+	if s == "" {
+		return list
+	}
+	return append(list, s)
+}
+
+// hafsNumbers mengembalikan nomor ayat Hafs yang dicakup ayat, dipakai untuk mencari terjemahan tambahan.
+// Bagian kedua dan seterusnya dari ayat Hafs yang dipecah tidak mendapat terjemahan (lihat toRiwayah).
+func hafsNumbers(ayah *domain.Ayah) []int {
+	if ayah.HafsPart > 1 {
+		return nil
+	}
+	if len(ayah.HafsNumbers) > 0 {
+		return ayah.HafsNumbers
+	}
+	return []int{ayah.Number}
+}
+
+// getRiwayahAyah mengambil satu ayat dengan penomoran riwayat selain Hafs
+func (uc *QuranUC) getRiwayahAyah(ctx context.Context, riwayah *domain.Riwayah, surahNumber, ayahNumber int) (*domain.Ayah, error) {
+	mappings, err := uc.riwayahRepo.GetMappings(ctx, riwayah.Code, []int{surahNumber})
+	if err != nil {
+		return nil, err
+	}
+
+	var hafs []domain.Ayah
+	for _, m := range mappings {
+		if m.Number != ayahNumber {
+			continue
+		}
+		for n := m.HafsFrom; n <= m.HafsTo; n++ {
+			ayah, err := uc.ayahRepo.GetSpecificAyah(ctx, surahNumber, n)
+			if err == domain.ErrNotFound {
+				continue
+			}
+			if err != nil {
+				return nil, err
+			}
+			hafs = append(hafs, *ayah)
+		}
+	}
+
+	ayahs, err := uc.toRiwayah(ctx, riwayah, hafs)
+	if err != nil {
+		return nil, err
+	}
+	for i := range ayahs {
+		if ayahs[i].Number == ayahNumber {
+			return &ayahs[i], nil
+		}
+	}
+	return nil, domain.ErrNotFound
+}
+
+// applyRiwayahCounts mengganti jumlah ayat tiap surah dengan penomoran riwayat yang dipilih.
+// AvailableAyahs adalah jumlah ayat yang sudah punya teks riwayat, sehingga IsComplete hanya bernilai
+// true jika seluruh ayat surah tersebut bisa disajikan. Surah yang belum dimuat untuk riwayat tersebut
+// tetap memakai jumlah ayat Hafs dengan AvailableAyahs 0.
+func (uc *QuranUC) applyRiwayahCounts(ctx context.Context, surahs []domain.Surah, riwayah *domain.Riwayah) ([]domain.Surah, error) {
+	if riwayah.Code == domain.DefaultRiwayah {
+		return surahs, nil
+	}
+
+	counts, err := uc.riwayahCounts(ctx, riwayah)
+	if err != nil {
+		return nil, err
+	}
+	texts, err := uc.riwayahRepo.GetTextCounts(ctx, riwayah.Code)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]domain.Surah, len(surahs))
+	for i, s := range surahs {
+		s.AvailableAyahs = 0
+		if count, ok := counts[s.Number]; ok {
+			s.TotalAyahs = count
+			s.AvailableAyahs = texts[s.Number]
+		}
+		s.IsComplete = s.AvailableAyahs > 0 && s.AvailableAyahs == s.TotalAyahs
+		result[i] = s
+	}
+	return result, nil
+}
+
+// toRiwayahSajdahs mengganti nomor ayat sajdah dengan ayat riwayat yang mencakupnya.
+// Jika ayat Hafs dipecah, sajdah jatuh pada bagian terakhirnya.
+func (uc *QuranUC) toRiwayahSajdahs(ctx context.Context, riwayah *domain.Riwayah, sajdahs []domain.Sajdah) ([]domain.Sajdah, error) {
+	if riwayah.Code == domain.DefaultRiwayah {
+		return sajdahs, nil
+	}
+
+	var surahs []int
+	for _, s := range sajdahs {
+		surahs = append(surahs, int(s.SurahID))
+	}
+
+	mappings, err := uc.riwayahRepo.GetMappings(ctx, riwayah.Code, surahs)
+	if err != nil {
+		return nil, err
+	}
+	numbers := make(map[[2]int]int)
+	for _, m := range mappings {
+		for n := m.HafsFrom; n <= m.HafsTo; n++ {
+			numbers[[2]int{int(m.SurahID), n}] = m.Number
+		}
+	}
+
+	result := make([]domain.Sajdah, 0, len(sajdahs))
+	for _, s := range sajdahs {
+		number, ok := numbers[[2]int{int(s.SurahID), s.AyahNumber}]
+		if !ok {
+			return nil, domain.ErrRiwayahUnavailable
+		}
+		s.AyahNumber = number
+		result = append(result, s)
+	}
+	return result, nil
+}
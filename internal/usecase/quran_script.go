@@ -0,0 +1,64 @@
+package usecase
+
+import (
+	"context"
+
+	"khalif-alquran/internal/domain"
+
+)
+
+// resolveScript memvalidasi edisi rasm dari ?script= atau preferensi user; kosong berarti DefaultScript
+func resolveScript(script string) (string, error) {
+	switch script {
+	case "":
+		return domain.DefaultScript, nil
+	case domain.ScriptUthmani, domain.ScriptImlaei, domain.ScriptIndopak:
+		return script, nil
+	default:
+		return "", domain.ErrUnknownScript
+	}
+}
+
+// applySurahScript mengganti text_arabic seluruh ayat dengan edisi rasm yang diminta.
+// Ayat yang belum punya teks di edisi tersebut tetap memakai DefaultScript, ditandai lewat Ayah.Script.
+func (uc *QuranUC) applySurahScript(ctx context.Context, surah *domain.Surah, script string) error {
+	texts := make(map[int]string)
+	if script != domain.DefaultScript {
+		rows, err := uc.ayahRepo.GetScriptBySurah(ctx, script, surah.Number)
+		if err != nil {
+			return err
+		}
+		for _, row := range rows {
+			texts[row.AyahNumber] = row.Text
+		}
+	}
+
+	for i := range surah.Ayahs {
+		ayah := &surah.Ayahs[i]
+		ayah.Script = domain.DefaultScript
+		if text, ok := texts[ayah.Number]; ok {
+			ayah.TextArabic = text
+			ayah.Script = script
+		}
+	}
+	return nil
+}
+
+func (uc *QuranUC) applyAyahScript(ctx context.Context, ayah *domain.Ayah, script string) error {
+	ayah.Script = domain.DefaultScript
+	if script == domain.DefaultScript {
+		return nil
+	}
+
+	row, err := uc.ayahRepo.GetScriptByAyah(ctx, script, int(ayah.SurahID), ayah.Number)
+	if err == domain.ErrNotFound {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	ayah.TextArabic = row.Text
+	ayah.Script = script
+	return nil
+}
@@ -0,0 +1,64 @@
+package usecase
+
+import (
+	"strings"
+
+	"khalif-alquran/internal/domain"
+
+)
+
+// Nilai ?revelation= yang diterima, termasuk istilah Indonesia
+var revelationTypes = map[string]string{
+	"meccan":    domain.RevelationMeccan,
+	"makkiyah":  domain.RevelationMeccan,
+	"medinan":   domain.RevelationMedinan,
+	"madaniyah": domain.RevelationMedinan,
+}
+
+var searchFields = map[string]bool{
+	domain.SearchFieldTranslation: true,
+	domain.SearchFieldLatin:       true,
+	domain.SearchFieldArabic:      true,
+	domain.SearchFieldTafsir:      true,
+}
+
+// resolveSearchOptions memvalidasi filter pencarian dan mengisi nilai default: seluruh surah (1-114)
+// dan SearchDefaultLimit ayat per halaman
+func resolveSearchOptions(opts domain.SearchOptions) (domain.SearchOptions, error) {
+	if opts.SurahFrom == 0 {
+		opts.SurahFrom = 1
+	}
+	if opts.SurahTo == 0 {
+		opts.SurahTo = 114
+	}
+	if opts.SurahFrom < 1 || opts.SurahTo > 114 || opts.SurahFrom > opts.SurahTo {
+		return opts, domain.ErrInvalidSearchFilter
+	}
+
+	if opts.Juz < 0 || opts.Juz > domain.DivisionTotals[domain.DivisionJuz] {
+		return opts, domain.ErrInvalidSearchFilter
+	}
+
+	if opts.RevelationType != "" {
+		revelation, ok := revelationTypes[strings.ToLower(opts.RevelationType)]
+		if !ok {
+			return opts, domain.ErrInvalidSearchFilter
+		}
+		opts.RevelationType = revelation
+	}
+
+	for _, field := range opts.Fields {
+		if !searchFields[field] {
+			return opts, domain.ErrInvalidSearchFilter
+		}
+	}
+
+	if opts.Limit == 0 {
+		opts.Limit = domain.SearchDefaultLimit
+	}
+	if opts.Limit < 1 || opts.Limit > domain.SearchMaxLimit {
+		return opts, domain.ErrInvalidSearchFilter
+	}
+
+	return opts, nil
+}
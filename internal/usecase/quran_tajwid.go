@@ -0,0 +1,183 @@
+package usecase
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"time"
+
+	"khalif-alquran/internal/domain"
+
+)
+
+// resolveRender memvalidasi mode render dari ?render=; kosong berarti teks biasa
+func resolveRender(render string) error {
+	switch render {
+	case "", domain.RenderTajwid:
+		return nil
+	default:
+		return domain.ErrUnknownRender
+	}
+}
+
+// wantsTajwid bernilai true jika anotasi tajwid perlu dimuat untuk opsi tersebut
+func wantsTajwid(opts domain.AyahOptions) bool {
+	return opts.Tajwid || opts.Render == domain.RenderTajwid
+}
+
+// getSurahTajwid mengembalikan anotasi tajwid satu surah pada edisi rasm tertentu
+func (uc *QuranUC) getSurahTajwid(ctx context.Context, script string, number int) ([]domain.TajwidAnnotation, error) {
+	cacheKey := fmt.Sprintf("%s%s:%d", domain.CacheKeyTajwidPrefix, script, number)
+
+	if uc.redisRepo != nil {
+		cachedData, err := uc.redisRepo.Get(ctx, cacheKey)
+		if err == nil && cachedData != "" {
+			var annotations []domain.TajwidAnnotation
+			if err := json.Unmarshal([]byte(cachedData), &annotations); err == nil {
+				return annotations, nil
+			}
+		}
+	}
+
+	annotations, err := uc.tajwidRepo.GetBySurah(ctx, script, number)
+	if err != nil {
+		return nil, err
+	}
+
+	if uc.redisRepo != nil {
+		if data, err := json.Marshal(annotations); err == nil {
+			_ = uc.redisRepo.Set(ctx, cacheKey, data, 24*time.Hour)
+		}
+	}
+
+	return annotations, nil
+}
+
+// tajwidColors memetakan kode hukum ke warna yang disarankan katalog
+func (uc *QuranUC) tajwidColors(ctx context.Context) (map[string]string, error) {
+	rules, err := getTajwidRules(ctx, uc.tajwidRepo, uc.redisRepo)
+	if err != nil {
+		return nil, err
+	}
+	colors := make(map[string]string, len(rules))
+	for _, r := range rules {
+		colors[r.Code] = r.Color
+	}
+	return colors, nil
+}
+
+// applySurahTajwid mengisi anotasi dan/atau span tajwid seluruh ayat di surah. Anotasi dipilih
+// sesuai Ayah.Script, karena ayat yang belum punya teks di edisi yang diminta memakai DefaultScript.
+func (uc *QuranUC) applySurahTajwid(ctx context.Context, surah *domain.Surah, opts domain.AyahOptions) error {
+	var colors map[string]string
+	if opts.Render == domain.RenderTajwid {
+		var err error
+		if colors, err = uc.tajwidColors(ctx); err != nil {
+			return err
+		}
+	}
+
+	byScript := make(map[string]map[int][]domain.TajwidAnnotation)
+	for i := range surah.Ayahs {
+		ayah := &surah.Ayahs[i]
+		script := ayah.Script
+		if script == "" {
+			script = domain.DefaultScript
+		}
+
+		byAyah, ok := byScript[script]
+		if !ok {
+			annotations, err := uc.getSurahTajwid(ctx, script, surah.Number)
+			if err != nil {
+				return err
+			}
+			byAyah = make(map[int][]domain.TajwidAnnotation)
+			for _, a := range annotations {
+				byAyah[a.AyahNumber] = append(byAyah[a.AyahNumber], a)
+			}
+			byScript[script] = byAyah
+		}
+
+		applyTajwid(ayah, byAyah[ayah.Number], opts, colors)
+	}
+	return nil
+}
+
+func (uc *QuranUC) applyAyahTajwid(ctx context.Context, ayah *domain.Ayah, opts domain.AyahOptions) error {
+	var colors map[string]string
+	if opts.Render == domain.RenderTajwid {
+		var err error
+		if colors, err = uc.tajwidColors(ctx); err != nil {
+			return err
+		}
+	}
+
+	script := ayah.Script
+	if script == "" {
+		script = domain.DefaultScript
+	}
+	annotations, err := uc.tajwidRepo.GetByAyah(ctx, script, int(ayah.SurahID), ayah.Number)
+	if err != nil {
+		return err
+	}
+
+	applyTajwid(ayah, annotations, opts, colors)
+	return nil
+}
+
+func applyTajwid(ayah *domain.Ayah, annotations []domain.TajwidAnnotation, opts domain.AyahOptions, colors map[string]string) {
+	if opts.Tajwid {
+		ayah.Tajwid = annotations
+		if ayah.Tajwid == nil {
+			ayah.Tajwid = []domain.TajwidAnnotation{}
+		}
+	}
+	if opts.Render == domain.RenderTajwid {
+		ayah.TajwidSpans = tajwidSpans(ayah.TextArabic, annotations, colors)
+	}
+}
+
+// tajwidSpans memecah teks ayat di setiap awal dan akhir anotasi, sehingga menggabungkan semua
+// Text berurutan menghasilkan teks aslinya. Potongan yang dicakup beberapa hukum memuat semua kodenya,
+// dengan warna dari hukum yang rentangnya paling pendek. Anotasi di luar panjang teks diabaikan.
+func tajwidSpans(text string, annotations []domain.TajwidAnnotation, colors map[string]string) []domain.TajwidSpan {
+	runes := []rune(text)
+
+	valid := make([]domain.TajwidAnnotation, 0, len(annotations))
+	bounds := map[int]bool{0: true, len(runes): true}
+	for _, a := range annotations {
+		if a.Start < 0 || a.End > len(runes) || a.Start >= a.End {
+			continue
+		}
+		valid = append(valid, a)
+		bounds[a.Start] = true
+		bounds[a.End] = true
+	}
+
+	points := make([]int, 0, len(bounds))
+	for p := range bounds {
+		points = append(points, p)
+	}
+	sort.Ints(points)
+
+	spans := make([]domain.TajwidSpan, 0, len(points))
+	for i := 0; i+1 < len(points); i++ {
+		from, to := points[i], points[i+1]
+		span := domain.TajwidSpan{Text: string(runes[from:to])}
+
+		width := 0
+		for _, a := range valid {
+			if a.Start > from || a.End < to {
+				continue
+			}
+			span.Rules = append(span.Rules, a.Rule)
+			if w := a.End - a.Start; width == 0 || w < width {
+				span.Color = colors[a.Rule]
+				width = w
+			}
+		}
+		spans = append(spans, span)
+	}
+	return spans
+}
@@ -0,0 +1,164 @@
+package usecase
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"khalif-alquran/internal/domain"
+
+)
+
+func (uc *QuranUC) GetTranslations(ctx context.Context) ([]domain.Translation, error) {
+	return uc.translationRepo.GetAll(ctx)
+}
+
+// resolveTranslations mencocokkan kode edisi dengan katalog, urut sesuai permintaan dan tanpa duplikat
+func (uc *QuranUC) resolveTranslations(ctx context.Context, codes []string) ([]domain.Translation, error) {
+	if len(codes) == 0 {
+		return nil, nil
+	}
+
+	var unique []string
+	seen := make(map[string]bool, len(codes))
+	for _, code := range codes {
+		if code == "" || seen[code] {
+			continue
+		}
+		seen[code] = true
+		unique = append(unique, code)
+	}
+
+	catalog, err := uc.translationRepo.GetByCodes(ctx, unique)
+	if err != nil {
+		return nil, err
+	}
+
+	byCode := make(map[string]domain.Translation, len(catalog))
+	for _, t := range catalog {
+		byCode[t.Code] = t
+	}
+
+	editions := make([]domain.Translation, 0, len(unique))
+	for _, code := range unique {
+		t, ok := byCode[code]
+		if !ok {
+			return nil, fmt.Errorf("%w: %s", domain.ErrUnknownTranslation, code)
+		}
+		editions = append(editions, t)
+	}
+	return editions, nil
+}
+
+// applySurahTranslations mengisi Ayah.Translations untuk seluruh ayat di surah.
+// Edisi default diambil dari kolom translation, sisanya dari ayah_translations. Untuk ayat riwayat
+// selain Hafs, terjemahan semua ayat Hafs yang dicakupnya digabung.
+func (uc *QuranUC) applySurahTranslations(ctx context.Context, surah *domain.Surah, editions []domain.Translation) error {
+	texts := make(map[string]map[int]string, len(editions))
+	for _, edition := range editions {
+		if edition.Code == domain.DefaultTranslation {
+			continue
+		}
+		byAyah, err := uc.getSurahTranslation(ctx, edition.Code, surah.Number)
+		if err != nil {
+			return err
+		}
+		texts[edition.Code] = byAyah
+	}
+
+	for i := range surah.Ayahs {
+		ayah := &surah.Ayahs[i]
+		ayah.Translations = make([]domain.AyahTranslation, 0, len(editions))
+		for _, edition := range editions {
+			text := ayah.Translation
+			if edition.Code != domain.DefaultTranslation {
+				var parts []string
+				for _, n := range hafsNumbers(ayah) {
+					parts = appendNonEmpty(parts, texts[edition.Code][n])
+				}
+				text = strings.Join(parts, " ")
+			}
+			ayah.Translations = append(ayah.Translations, domain.AyahTranslation{
+				Edition:    edition.Code,
+				SurahID:    ayah.SurahID,
+				AyahNumber: ayah.Number,
+				Language:   edition.Language,
+				Text:       text,
+			})
+		}
+	}
+	return nil
+}
+
+func (uc *QuranUC) applyAyahTranslations(ctx context.Context, ayah *domain.Ayah, editions []domain.Translation) error {
+	var codes []string
+	for _, edition := range editions {
+		if edition.Code != domain.DefaultTranslation {
+			codes = append(codes, edition.Code)
+		}
+	}
+
+	texts := make(map[string][]string, len(codes))
+	if len(codes) > 0 {
+		for _, n := range hafsNumbers(ayah) {
+			rows, err := uc.translationRepo.GetByAyah(ctx, codes, int(ayah.SurahID), n)
+			if err != nil {
+				return err
+			}
+			for _, row := range rows {
+				texts[row.Edition] = appendNonEmpty(texts[row.Edition], row.Text)
+			}
+		}
+	}
+
+	ayah.Translations = make([]domain.AyahTranslation, 0, len(editions))
+	for _, edition := range editions {
+		text := ayah.Translation
+		if edition.Code != domain.DefaultTranslation {
+			text = strings.Join(texts[edition.Code], " ")
+		}
+		ayah.Translations = append(ayah.Translations, domain.AyahTranslation{
+			Edition:    edition.Code,
+			SurahID:    ayah.SurahID,
+			AyahNumber: ayah.Number,
+			Language:   edition.Language,
+			Text:       text,
+		})
+	}
+	return nil
+}
+
+// getSurahTranslation mengembalikan teks satu edisi per nomor ayat, di-cache per edisi per surah
+func (uc *QuranUC) getSurahTranslation(ctx context.Context, edition string, surahNumber int) (map[int]string, error) {
+	cacheKey := fmt.Sprintf("%s%s:%d", domain.CacheKeyTranslationPrefix, edition, surahNumber)
+
+	if uc.redisRepo != nil {
+		cachedData, err := uc.redisRepo.Get(ctx, cacheKey)
+		if err == nil && cachedData != "" {
+			var texts map[int]string
+			if err := json.Unmarshal([]byte(cachedData), &texts); err == nil {
+				return texts, nil
+			}
+		}
+	}
+
+	rows, err := uc.translationRepo.GetBySurah(ctx, edition, surahNumber)
+	if err != nil {
+		return nil, err
+	}
+
+	texts := make(map[int]string, len(rows))
+	for _, row := range rows {
+		texts[row.AyahNumber] = row.Text
+	}
+
+	if uc.redisRepo != nil {
+		if data, err := json.Marshal(texts); err == nil {
+			_ = uc.redisRepo.Set(ctx, cacheKey, data, 24*time.Hour)
+		}
+	}
+
+	return texts, nil
+}
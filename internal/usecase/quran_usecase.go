@@ -11,21 +11,45 @@ import (
 )
 
 type QuranUC struct {
-	surahRepo domain.SurahRepository
-	ayahRepo  domain.AyahRepository
-	redisRepo domain.RedisRepository
+	surahRepo       domain.SurahRepository
+	ayahRepo        domain.AyahRepository
+	divisionRepo    domain.DivisionRepository
+	translationRepo domain.TranslationRepository
+	wordRepo        domain.WordRepository
+	riwayahRepo     domain.RiwayahRepository
+	tajwidRepo      domain.TajwidRepository
+	redisRepo       domain.RedisRepository
 }
 
 // NewQuranUseCase mengembalikan *QuranUC (Struct Pointer)
-func NewQuranUseCase(surahRepo domain.SurahRepository, ayahRepo domain.AyahRepository, redisRepo domain.RedisRepository) *QuranUC {
+func NewQuranUseCase(surahRepo domain.SurahRepository, ayahRepo domain.AyahRepository, divisionRepo domain.DivisionRepository, translationRepo domain.TranslationRepository, wordRepo domain.WordRepository, riwayahRepo domain.RiwayahRepository, tajwidRepo domain.TajwidRepository, redisRepo domain.RedisRepository) *QuranUC {
 	return &QuranUC{
-		surahRepo: surahRepo,
-		ayahRepo:  ayahRepo,
-		redisRepo: redisRepo,
+		surahRepo:       surahRepo,
+		ayahRepo:        ayahRepo,
+		divisionRepo:    divisionRepo,
+		translationRepo: translationRepo,
+		wordRepo:        wordRepo,
+		riwayahRepo:     riwayahRepo,
+		tajwidRepo:      tajwidRepo,
+		redisRepo:       redisRepo,
 	}
 }
 
-func (uc *QuranUC) GetAllSurahs(ctx context.Context) ([]domain.Surah, error) {
+// GetAllSurahs mengembalikan katalog surah; untuk riwayat selain Hafs, jumlah ayatnya mengikuti riwayat tersebut
+func (uc *QuranUC) GetAllSurahs(ctx context.Context, riwayahCode string) ([]domain.Surah, error) {
+	riwayah, err := uc.resolveRiwayah(ctx, riwayahCode)
+	if err != nil {
+		return nil, err
+	}
+
+	surahs, err := uc.getAllSurahs(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return uc.applyRiwayahCounts(ctx, surahs, riwayah)
+}
+
+func (uc *QuranUC) getAllSurahs(ctx context.Context) ([]domain.Surah, error) {
 	cacheKey := domain.CacheKeySurahAll
 
 	if uc.redisRepo != nil {
@@ -52,8 +76,63 @@ func (uc *QuranUC) GetAllSurahs(ctx context.Context) ([]domain.Surah, error) {
 	return surahs, nil
 }
 
-func (uc *QuranUC) GetSurahDetail(ctx context.Context, number int) (*domain.Surah, error) {
-	cacheKey := fmt.Sprintf("%s%d", domain.CacheKeySurahPrefix, number)
+func (uc *QuranUC) GetSurahDetail(ctx context.Context, number int, opts domain.AyahOptions) (*domain.Surah, error) {
+	editions, err := uc.resolveTranslations(ctx, opts.Translations)
+	if err != nil {
+		return nil, err
+	}
+
+	script, err := resolveScript(opts.Script)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := resolveRender(opts.Render); err != nil {
+		return nil, err
+	}
+
+	riwayah, err := uc.resolveRiwayah(ctx, opts.Riwayah)
+	if err != nil {
+		return nil, err
+	}
+	if riwayah.Code != domain.DefaultRiwayah {
+		// Teks riwayat lain punya rasm sendiri, sedangkan kata per kata dan tajwid hanya tersedia untuk Hafs
+		if opts.Words || wantsTajwid(opts) {
+			return nil, domain.ErrRiwayahUnsupported
+		}
+		script = domain.DefaultScript
+	}
+
+	surah, err := uc.getSurah(ctx, number, riwayah, script)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(editions) > 0 {
+		if err := uc.applySurahTranslations(ctx, surah, editions); err != nil {
+			return nil, err
+		}
+	}
+
+	if opts.Words {
+		if err := uc.applySurahWords(ctx, surah); err != nil {
+			return nil, err
+		}
+	}
+
+	if wantsTajwid(opts) {
+		if err := uc.applySurahTajwid(ctx, surah, opts); err != nil {
+			return nil, err
+		}
+	}
+
+	return surah, nil
+}
+
+// getSurah mengambil detail surah beserta ayatnya (tanpa terjemahan tambahan) dari cache atau database.
+// Cache dipisah per riwayat dan edisi rasm karena nomor ayat dan text_arabic sudah diganti.
+func (uc *QuranUC) getSurah(ctx context.Context, number int, riwayah *domain.Riwayah, script string) (*domain.Surah, error) {
+	cacheKey := fmt.Sprintf("%s%s:%s:%d", domain.CacheKeySurahPrefix, riwayah.Code, script, number)
 
 	if uc.redisRepo != nil {
 		cachedData, err := uc.redisRepo.Get(ctx, cacheKey)
@@ -70,6 +149,28 @@ func (uc *QuranUC) GetSurahDetail(ctx context.Context, number int) (*domain.Sura
 		return nil, err
 	}
 
+	if err := uc.applySurahScript(ctx, surah, script); err != nil {
+		return nil, err
+	}
+
+	if riwayah.Code != domain.DefaultRiwayah {
+		counts, err := uc.riwayahCounts(ctx, riwayah)
+		if err != nil {
+			return nil, err
+		}
+		if counts[number] == 0 {
+			return nil, domain.ErrRiwayahUnavailable
+		}
+
+		surah.Ayahs, err = uc.toRiwayah(ctx, riwayah, surah.Ayahs)
+		if err != nil {
+			return nil, err
+		}
+		surah.TotalAyahs = counts[number]
+		surah.AvailableAyahs = len(surah.Ayahs)
+		surah.IsComplete = surah.AvailableAyahs == surah.TotalAyahs
+	}
+
 	if uc.redisRepo != nil {
 		if data, err := json.Marshal(surah); err == nil {
 			_ = uc.redisRepo.Set(ctx, cacheKey, data, 24*time.Hour)
@@ -79,26 +180,266 @@ func (uc *QuranUC) GetSurahDetail(ctx context.Context, number int) (*domain.Sura
 	return surah, nil
 }
 
-func (uc *QuranUC) GetAyahDetail(ctx context.Context, surahNumber, ayahNumber int) (*domain.Ayah, error) {
-	return uc.ayahRepo.GetSpecificAyah(ctx, surahNumber, ayahNumber)
+func (uc *QuranUC) GetAyahDetail(ctx context.Context, surahNumber, ayahNumber int, opts domain.AyahOptions) (*domain.Ayah, error) {
+	editions, err := uc.resolveTranslations(ctx, opts.Translations)
+	if err != nil {
+		return nil, err
+	}
+
+	script, err := resolveScript(opts.Script)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := resolveRender(opts.Render); err != nil {
+		return nil, err
+	}
+
+	riwayah, err := uc.resolveRiwayah(ctx, opts.Riwayah)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := uc.validateAyah(ctx, riwayah, surahNumber, ayahNumber); err != nil {
+		return nil, err
+	}
+
+	if riwayah.Code != domain.DefaultRiwayah {
+		if opts.Words || wantsTajwid(opts) {
+			return nil, domain.ErrRiwayahUnsupported
+		}
+
+		ayah, err := uc.getRiwayahAyah(ctx, riwayah, surahNumber, ayahNumber)
+		if err != nil {
+			return nil, err
+		}
+		if len(editions) > 0 {
+			if err := uc.applyAyahTranslations(ctx, ayah, editions); err != nil {
+				return nil, err
+			}
+		}
+		return ayah, nil
+	}
+
+	ayah, err := uc.ayahRepo.GetSpecificAyah(ctx, surahNumber, ayahNumber)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := uc.applyAyahScript(ctx, ayah, script); err != nil {
+		return nil, err
+	}
+
+	if len(editions) > 0 {
+		if err := uc.applyAyahTranslations(ctx, ayah, editions); err != nil {
+			return nil, err
+		}
+	}
+
+	if opts.Words {
+		words, err := uc.wordRepo.GetByAyah(ctx, surahNumber, ayahNumber)
+		if err != nil {
+			return nil, err
+		}
+		ayah.Words = words
+	}
+
+	if wantsTajwid(opts) {
+		if err := uc.applyAyahTajwid(ctx, ayah, opts); err != nil {
+			return nil, err
+		}
+	}
+
+	return ayah, nil
 }
 
-func (uc *QuranUC) Search(ctx context.Context, query string) (map[string]interface{}, error) {
-	surahs, err := uc.surahRepo.Search(ctx, query)
+// GetAyahByGlobalNumber mencari ayat berdasarkan nomor globalnya dalam penomoran riwayat yang dipilih.
+// Untuk riwayat selain Hafs, nomor global hanya tersedia jika riwayat tersebut sudah dimuat lengkap.
+func (uc *QuranUC) GetAyahByGlobalNumber(ctx context.Context, numberInQuran int, riwayahCode string) (*domain.Ayah, error) {
+	riwayah, err := uc.resolveRiwayah(ctx, riwayahCode)
 	if err != nil {
 		return nil, err
 	}
 
-	ayahs, err := uc.ayahRepo.Search(ctx, query)
+	if numberInQuran < 1 || numberInQuran > riwayah.TotalAyahs {
+		return nil, domain.ErrInvalidGlobalAyah
+	}
+	if riwayah.Code == domain.DefaultRiwayah {
+		return uc.ayahRepo.GetByGlobalNumber(ctx, numberInQuran)
+	}
+
+	counts, err := uc.riwayahCounts(ctx, riwayah)
 	if err != nil {
 		return nil, err
 	}
+	offsets := riwayahOffsets(riwayah, counts)
+	if offsets == nil {
+		return nil, domain.ErrRiwayahUnavailable
+	}
+
+	for number := 114; number >= 1; number-- {
+		if numberInQuran > offsets[number] {
+			return uc.getRiwayahAyah(ctx, riwayah, number, numberInQuran-offsets[number])
+		}
+	}
+	return nil, domain.ErrNotFound
+}
 
-	result := map[string]interface{}{
-		"surahs": surahs,
-		"ayahs":  ayahs,
+// GetDivision mengembalikan rentang dan isi ayat satu juz/hizb/rub'/manzil/ruku'.
+// Batas pembagian disimpan dalam penomoran Hafs; untuk riwayat lain, ayat dan batasnya dikonversi.
+func (uc *QuranUC) GetDivision(ctx context.Context, divisionType string, number int, riwayahCode string) (*domain.Division, error) {
+	if total, ok := domain.DivisionTotals[divisionType]; number < 1 || (ok && number > total) {
+		return nil, domain.ErrInvalidDivision
 	}
 
+	riwayah, err := uc.resolveRiwayah(ctx, riwayahCode)
+	if err != nil {
+		return nil, err
+	}
+
+	division, err := uc.getDivision(ctx, divisionType, number)
+	if err != nil {
+		return nil, err
+	}
+	if riwayah.Code == domain.DefaultRiwayah {
+		return division, nil
+	}
+
+	division.Ayahs, err = uc.toRiwayah(ctx, riwayah, division.Ayahs)
+	if err != nil {
+		return nil, err
+	}
+	if n := len(division.Ayahs); n > 0 {
+		first, last := division.Ayahs[0], division.Ayahs[n-1]
+		division.StartSurah, division.StartAyah = int(first.SurahID), first.Number
+		division.EndSurah, division.EndAyah = int(last.SurahID), last.Number
+		division.FirstAyah, division.LastAyah = first.NumberInQuran, last.NumberInQuran
+	}
+	return division, nil
+}
+
+func (uc *QuranUC) getDivision(ctx context.Context, divisionType string, number int) (*domain.Division, error) {
+	cacheKey := fmt.Sprintf("%s%s:%d", domain.CacheKeyDivisionPrefix, divisionType, number)
+
+	if uc.redisRepo != nil {
+		cachedData, err := uc.redisRepo.Get(ctx, cacheKey)
+		if err == nil && cachedData != "" {
+			var division domain.Division
+			if err := json.Unmarshal([]byte(cachedData), &division); err == nil {
+				return &division, nil
+			}
+		}
+	}
+
+	division, err := uc.divisionRepo.GetByNumber(ctx, divisionType, number)
+	if err != nil {
+		return nil, err
+	}
+
+	division.Ayahs, err = uc.ayahRepo.GetByGlobalRange(ctx, division.FirstAyah, division.LastAyah)
+	if err != nil {
+		return nil, err
+	}
+
+	if uc.redisRepo != nil {
+		if data, err := json.Marshal(division); err == nil {
+			_ = uc.redisRepo.Set(ctx, cacheKey, data, 24*time.Hour)
+		}
+	}
+
+	return division, nil
+}
+
+// GetSajdahs mengembalikan 15 ayat sajdah tilawah beserta hukumnya per mazhab
+func (uc *QuranUC) GetSajdahs(ctx context.Context, riwayahCode string) ([]domain.Sajdah, error) {
+	riwayah, err := uc.resolveRiwayah(ctx, riwayahCode)
+	if err != nil {
+		return nil, err
+	}
+
+	sajdahs, err := uc.getSajdahs(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return uc.toRiwayahSajdahs(ctx, riwayah, sajdahs)
+}
+
+func (uc *QuranUC) getSajdahs(ctx context.Context) ([]domain.Sajdah, error) {
+	cacheKey := domain.CacheKeySajdahAll
+
+	if uc.redisRepo != nil {
+		cachedData, err := uc.redisRepo.Get(ctx, cacheKey)
+		if err == nil && cachedData != "" {
+			var sajdahs []domain.Sajdah
+			if err := json.Unmarshal([]byte(cachedData), &sajdahs); err == nil {
+				return sajdahs, nil
+			}
+		}
+	}
+
+	sajdahs, err := uc.divisionRepo.GetSajdahs(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if uc.redisRepo != nil {
+		if data, err := json.Marshal(sajdahs); err == nil {
+			_ = uc.redisRepo.Set(ctx, cacheKey, data, 24*time.Hour)
+		}
+	}
+
+	return sajdahs, nil
+}
+
+// Search mencari di teks Hafs, lalu hasilnya dikonversi ke penomoran riwayat yang dipilih.
+// Jika query berupa rujukan ayat (misal "2:255" atau "QS. Yasin 1-12"), ayat-ayatnya dikembalikan
+// sebagai grup reference di depan hasil pencarian kata kunci. Reference dan surah hanya dicari di
+// halaman pertama; halaman berikutnya (opts.Cursor) hanya berisi ayat.
+func (uc *QuranUC) Search(ctx context.Context, query string, opts domain.SearchOptions) (*domain.SearchResult, error) {
+	opts, err := resolveSearchOptions(opts)
+	if err != nil {
+		return nil, err
+	}
+
+	riwayah, err := uc.resolveRiwayah(ctx, opts.Riwayah)
+	if err != nil {
+		return nil, err
+	}
+
+	var reference *domain.ResolvedReference
+	surahs := []domain.Surah{}
+	if opts.Cursor == "" {
+		reference, err = uc.ResolveReference(ctx, query, riwayah.Code)
+		switch err {
+		case nil:
+		case domain.ErrInvalidReference, domain.ErrInvalidSurahNumber, domain.ErrInvalidAyahNumber, domain.ErrNotFound:
+			// Bukan rujukan ayat yang valid, cukup hasil pencarian kata kunci
+			reference = nil
+		default:
+			return nil, err
+		}
+
+		surahs, err = uc.surahRepo.Search(ctx, query, opts)
+		if err != nil {
+			return nil, err
+		}
+		surahs, err = uc.applyRiwayahCounts(ctx, surahs, riwayah)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	result, err := uc.ayahRepo.Search(ctx, query, opts)
+	if err != nil {
+		return nil, err
+	}
+	result.Ayahs, err = uc.toRiwayah(ctx, riwayah, result.Ayahs)
+	if err != nil {
+		return nil, err
+	}
+
+	result.Reference = reference
+	result.Surahs = surahs
 	return result, nil
 }
 
@@ -118,5 +459,45 @@ func (uc *QuranUC) ClearCache(ctx context.Context) error {
 		return err
 	}
 
+	// 3. Hapus Cache Isi Juz/Hizb/dst (termasuk daftar sajdah)
+	if err := uc.redisRepo.DeletePrefix(ctx, domain.CacheKeyDivisionPrefix); err != nil {
+		return err
+	}
+
+	// 4. Hapus Cache Halaman Mushaf
+	if err := uc.redisRepo.DeletePrefix(ctx, domain.CacheKeyMushafPrefix); err != nil {
+		return err
+	}
+
+	// 5. Hapus Cache Terjemahan per Edisi
+	if err := uc.redisRepo.DeletePrefix(ctx, domain.CacheKeyTranslationPrefix); err != nil {
+		return err
+	}
+
+	// 6. Hapus Cache Entri Tafsir
+	if err := uc.redisRepo.DeletePrefix(ctx, domain.CacheKeyTafsirPrefix); err != nil {
+		return err
+	}
+
+	// 7. Hapus Cache Kata per Kata
+	if err := uc.redisRepo.DeletePrefix(ctx, domain.CacheKeyWordPrefix); err != nil {
+		return err
+	}
+
+	// 8. Hapus Cache Akar Kata (termasuk daftar frekuensi)
+	if err := uc.redisRepo.DeletePrefix(ctx, domain.CacheKeyMorphologyPrefix); err != nil {
+		return err
+	}
+
+	// 9. Hapus Cache Jumlah Ayat per Riwayat
+	if err := uc.redisRepo.DeletePrefix(ctx, domain.CacheKeyRiwayahPrefix); err != nil {
+		return err
+	}
+
+	// 10. Hapus Cache Anotasi Tajwid (termasuk katalog hukum)
+	if err := uc.redisRepo.DeletePrefix(ctx, domain.CacheKeyTajwidPrefix); err != nil {
+		return err
+	}
+
 	return nil
 }
\ No newline at end of file
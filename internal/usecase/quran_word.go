@@ -0,0 +1,71 @@
+package usecase
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"khalif-alquran/internal/domain"
+
+)
+
+// GetSurahWords mengembalikan kata per kata seluruh ayat dalam satu surah.
+// Surah yang belum punya data kata per kata menghasilkan list kosong. Data kata per kata
+// mengikuti teks dan penomoran Hafs, sehingga riwayat lain ditolak.
+func (uc *QuranUC) GetSurahWords(ctx context.Context, number int, riwayahCode string) ([]domain.Word, error) {
+	if number < 1 || number > 114 {
+		return nil, domain.ErrInvalidSurahNumber
+	}
+
+	riwayah, err := uc.resolveRiwayah(ctx, riwayahCode)
+	if err != nil {
+		return nil, err
+	}
+	if riwayah.Code != domain.DefaultRiwayah {
+		return nil, domain.ErrRiwayahUnsupported
+	}
+
+	cacheKey := fmt.Sprintf("%s%d", domain.CacheKeyWordPrefix, number)
+
+	if uc.redisRepo != nil {
+		cachedData, err := uc.redisRepo.Get(ctx, cacheKey)
+		if err == nil && cachedData != "" {
+			var words []domain.Word
+			if err := json.Unmarshal([]byte(cachedData), &words); err == nil {
+				return words, nil
+			}
+		}
+	}
+
+	words, err := uc.wordRepo.GetBySurah(ctx, number)
+	if err != nil {
+		return nil, err
+	}
+
+	if uc.redisRepo != nil {
+		if data, err := json.Marshal(words); err == nil {
+			_ = uc.redisRepo.Set(ctx, cacheKey, data, 24*time.Hour)
+		}
+	}
+
+	return words, nil
+}
+
+// applySurahWords mengisi Ayah.Words untuk seluruh ayat di surah
+func (uc *QuranUC) applySurahWords(ctx context.Context, surah *domain.Surah) error {
+	words, err := uc.GetSurahWords(ctx, surah.Number, domain.DefaultRiwayah)
+	if err != nil {
+		return err
+	}
+
+	byAyah := make(map[int][]domain.Word)
+	for _, w := range words {
+		byAyah[w.AyahNumber] = append(byAyah[w.AyahNumber], w)
+	}
+
+	for i := range surah.Ayahs {
+		surah.Ayahs[i].Words = byAyah[surah.Ayahs[i].Number]
+	}
+	return nil
+}
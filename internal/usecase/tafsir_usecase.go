@@ -0,0 +1,67 @@
+package usecase
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"khalif-alquran/internal/domain"
+
+)
+
+type TafsirUC struct {
+	tafsirRepo domain.TafsirRepository
+	redisRepo  domain.RedisRepository
+}
+
+func NewTafsirUseCase(tafsirRepo domain.TafsirRepository, redisRepo domain.RedisRepository) *TafsirUC {
+	return &TafsirUC{
+		tafsirRepo: tafsirRepo,
+		redisRepo:  redisRepo,
+	}
+}
+
+func (uc *TafsirUC) GetEditions(ctx context.Context) ([]domain.TafsirEdition, error) {
+	return uc.tafsirRepo.GetEditions(ctx)
+}
+
+// GetEntry mengembalikan entri tafsir yang mencakup ayat tersebut. Untuk tafsir per kelompok ayat,
+// semua ayat dalam rentang mendapat entri yang sama (from_ayah/to_ayah menunjukkan cakupannya).
+// Tafsir mengikuti penomoran Hafs, sehingga riwayat lain ditolak.
+func (uc *TafsirUC) GetEntry(ctx context.Context, edition string, surahNumber, ayahNumber int, riwayahCode string) (*domain.TafsirEntry, error) {
+	if err := requireHafs(riwayahCode); err != nil {
+		return nil, err
+	}
+	if surahNumber < 1 || surahNumber > 114 {
+		return nil, domain.ErrInvalidSurahNumber
+	}
+	if ayahNumber < 1 {
+		return nil, domain.ErrInvalidAyahNumber
+	}
+
+	cacheKey := fmt.Sprintf("%s%s:%d:%d", domain.CacheKeyTafsirPrefix, edition, surahNumber, ayahNumber)
+
+	if uc.redisRepo != nil {
+		cachedData, err := uc.redisRepo.Get(ctx, cacheKey)
+		if err == nil && cachedData != "" {
+			var cached domain.TafsirEntry
+			if err := json.Unmarshal([]byte(cachedData), &cached); err == nil {
+				return &cached, nil
+			}
+		}
+	}
+
+	entry, err := uc.tafsirRepo.GetCoveringEntry(ctx, edition, surahNumber, ayahNumber)
+	if err != nil {
+		return nil, err
+	}
+
+	if uc.redisRepo != nil {
+		if data, err := json.Marshal(entry); err == nil {
+			_ = uc.redisRepo.Set(ctx, cacheKey, data, 24*time.Hour)
+		}
+	}
+
+	return entry, nil
+}
@@ -0,0 +1,55 @@
+package usecase
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"khalif-alquran/internal/domain"
+
+)
+
+type TajwidUC struct {
+	tajwidRepo domain.TajwidRepository
+	redisRepo  domain.RedisRepository
+}
+
+func NewTajwidUseCase(tajwidRepo domain.TajwidRepository, redisRepo domain.RedisRepository) *TajwidUC {
+	return &TajwidUC{
+		tajwidRepo: tajwidRepo,
+		redisRepo:  redisRepo,
+	}
+}
+
+// GetRules mengembalikan katalog hukum tajwid beserta nama tampilan dan warna yang disarankan
+func (uc *TajwidUC) GetRules(ctx context.Context) ([]domain.TajwidRuleDefinition, error) {
+	return getTajwidRules(ctx, uc.tajwidRepo, uc.redisRepo)
+}
+
+// getTajwidRules dipakai bersama oleh TajwidUC dan QuranUC (untuk warna pada mode render tajwid)
+func getTajwidRules(ctx context.Context, tajwidRepo domain.TajwidRepository, redisRepo domain.RedisRepository) ([]domain.TajwidRuleDefinition, error) {
+	cacheKey := domain.CacheKeyTajwidRules
+
+	if redisRepo != nil {
+		cachedData, err := redisRepo.Get(ctx, cacheKey)
+		if err == nil && cachedData != "" {
+			var rules []domain.TajwidRuleDefinition
+			if err := json.Unmarshal([]byte(cachedData), &rules); err == nil {
+				return rules, nil
+			}
+		}
+	}
+
+	rules, err := tajwidRepo.GetRules(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if redisRepo != nil {
+		if data, err := json.Marshal(rules); err == nil {
+			_ = redisRepo.Set(ctx, cacheKey, data, 24*time.Hour)
+		}
+	}
+
+	return rules, nil
+}
@@ -0,0 +1,358 @@
+package database
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"sort"
+	"strings"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	"khalif-alquran/internal/domain"
+
+)
+
+// divisions.json menyimpan posisi awal (surah, ayat) tiap pembagian. Akhir pembagian
+// tidak disimpan karena selalu tepat sebelum awal pembagian berikutnya.
+// Hizb tidak punya daftar sendiri: setiap hizb adalah empat rub' al-hizb.
+// Halaman ("page") diambil dari layout mushaf default di mushaf/, lihat mushaf.go.
+const seedDivisionFile = "divisions.json"
+
+type seedDivisionList struct {
+	Juz    [][2]int         `json:"juz"`
+	Rub    [][2]int         `json:"rub"`
+	Manzil [][2]int         `json:"manzil"`
+	Ruku   [][2]int         `json:"ruku"`
+	Sajdah []seedSajdahItem `json:"sajdah"`
+}
+
+// seedSajdahItem: hanafi/maliki/shafii/hanbali boleh kosong untuk corpus lama
+type seedSajdahItem struct {
+	Surah   int    `json:"surah"`
+	Ayah    int    `json:"ayah"`
+	Type    string `json:"type"`
+	Hanafi  string `json:"hanafi"`
+	Maliki  string `json:"maliki"`
+	Shafii  string `json:"shafii"`
+	Hanbali string `json:"hanbali"`
+}
+
+// ayahIndex memetakan posisi (surah, ayat) ke number_in_quran dan sebaliknya
+type ayahIndex struct {
+	numbers []int       // nomor surah, terurut
+	offsets map[int]int // jumlah ayat sebelum surah
+	counts  map[int]int // jumlah ayat dalam surah
+	total   int
+}
+
+func newAyahIndex(counts map[int]int) *ayahIndex {
+	idx := &ayahIndex{offsets: make(map[int]int, len(counts)), counts: counts}
+	for number := range counts {
+		idx.numbers = append(idx.numbers, number)
+	}
+	sort.Ints(idx.numbers)
+
+	for _, number := range idx.numbers {
+		idx.offsets[number] = idx.total
+		idx.total += counts[number]
+	}
+	return idx
+}
+
+func (idx *ayahIndex) global(pos [2]int) (int, error) {
+	count, ok := idx.counts[pos[0]]
+	if !ok {
+		return 0, fmt.Errorf("%d:%d: surah is not in the catalog", pos[0], pos[1])
+	}
+	if pos[1] < 1 || pos[1] > count {
+		return 0, fmt.Errorf("%d:%d: surah has %d ayahs", pos[0], pos[1], count)
+	}
+	return idx.offsets[pos[0]] + pos[1], nil
+}
+
+func (idx *ayahIndex) position(global int) (surah, ayah int) {
+	i := sort.Search(len(idx.numbers), func(i int) bool {
+		return idx.offsets[idx.numbers[i]]+idx.counts[idx.numbers[i]] >= global
+	})
+	number := idx.numbers[i]
+	return number, global - idx.offsets[number]
+}
+
+// loadSeedDivisions membaca divisions.json. File ini opsional agar folder SEED_DIR
+// lama (tanpa data pembagian) tetap bisa dipakai; hasilnya nil jika file tidak ada.
+// pages adalah awal halaman DefaultMushafLayout, boleh nil jika layout tersebut tidak ada.
+func loadSeedDivisions(source fs.FS, counts map[int]int, pages [][2]int) ([]domain.Division, []domain.Sajdah, error) {
+	data, err := fs.ReadFile(source, seedDivisionFile)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, nil, nil
+	}
+	if err != nil {
+		return nil, nil, fmt.Errorf("read %s: %w", seedDivisionFile, err)
+	}
+
+	var list seedDivisionList
+	if err := json.Unmarshal(data, &list); err != nil {
+		return nil, nil, fmt.Errorf("parse %s: %w", seedDivisionFile, err)
+	}
+
+	idx := newAyahIndex(counts)
+
+	var hizb [][2]int
+	for i := 0; i < len(list.Rub); i += 4 {
+		hizb = append(hizb, list.Rub[i])
+	}
+
+	var divisions []domain.Division
+	for _, group := range []struct {
+		divisionType string
+		starts       [][2]int
+	}{
+		{domain.DivisionJuz, list.Juz},
+		{domain.DivisionHizb, hizb},
+		{domain.DivisionRub, list.Rub},
+		{domain.DivisionManzil, list.Manzil},
+		{domain.DivisionRuku, list.Ruku},
+		{domain.DivisionPage, pages},
+	} {
+		rows, err := buildDivisions(idx, group.divisionType, group.starts)
+		if err != nil {
+			return nil, nil, fmt.Errorf("%s: %s: %w", seedDivisionFile, group.divisionType, err)
+		}
+		divisions = append(divisions, rows...)
+	}
+
+	sajdahs := make([]domain.Sajdah, 0, len(list.Sajdah))
+	for i, item := range list.Sajdah {
+		if _, err := idx.global([2]int{item.Surah, item.Ayah}); err != nil {
+			return nil, nil, fmt.Errorf("%s: sajdah: %w", seedDivisionFile, err)
+		}
+		if item.Type != domain.SajdahObligatory && item.Type != domain.SajdahRecommended {
+			return nil, nil, fmt.Errorf("%s: sajdah %d:%d: unknown type %q", seedDivisionFile, item.Surah, item.Ayah, item.Type)
+		}
+		for _, ruling := range []string{item.Hanafi, item.Maliki, item.Shafii, item.Hanbali} {
+			switch ruling {
+			case "", domain.SajdahObligatory, domain.SajdahRecommended, domain.SajdahNone:
+			default:
+				return nil, nil, fmt.Errorf("%s: sajdah %d:%d: unknown madhhab ruling %q", seedDivisionFile, item.Surah, item.Ayah, ruling)
+			}
+		}
+
+		sajdahs = append(sajdahs, domain.Sajdah{
+			Number:     i + 1,
+			SurahID:    uint(item.Surah),
+			AyahNumber: item.Ayah,
+			Type:       item.Type,
+			Madhhab: domain.SajdahMadhhab{
+				Hanafi:  item.Hanafi,
+				Maliki:  item.Maliki,
+				Shafii:  item.Shafii,
+				Hanbali: item.Hanbali,
+			},
+		})
+	}
+
+	return divisions, sajdahs, nil
+}
+
+func buildDivisions(idx *ayahIndex, divisionType string, starts [][2]int) ([]domain.Division, error) {
+	if len(starts) == 0 {
+		return nil, nil
+	}
+	if want, ok := domain.DivisionTotals[divisionType]; ok && len(starts) != want {
+		return nil, fmt.Errorf("expected %d entries, found %d", want, len(starts))
+	}
+
+	firsts := make([]int, len(starts))
+	for i, start := range starts {
+		global, err := idx.global(start)
+		if err != nil {
+			return nil, err
+		}
+		if i == 0 && global != 1 {
+			return nil, fmt.Errorf("first entry must start at 1:1")
+		}
+		if i > 0 && global <= firsts[i-1] {
+			return nil, fmt.Errorf("entry %d (%d:%d) does not come after the previous one", i+1, start[0], start[1])
+		}
+		firsts[i] = global
+	}
+
+	divisions := make([]domain.Division, len(starts))
+	for i, first := range firsts {
+		last := idx.total
+		if i+1 < len(firsts) {
+			last = firsts[i+1] - 1
+		}
+		endSurah, endAyah := idx.position(last)
+
+		divisions[i] = domain.Division{
+			Type:       divisionType,
+			Number:     i + 1,
+			StartSurah: starts[i][0],
+			StartAyah:  starts[i][1],
+			EndSurah:   endSurah,
+			EndAyah:    endAyah,
+			FirstAyah:  first,
+			LastAyah:   last,
+		}
+	}
+	return divisions, nil
+}
+
+// syncDivisions menyamakan tabel divisions dan sajdahs dengan data seed, mengembalikan jumlah
+// baris yang ditambah, diubah atau dihapus. Tanpa overwrite hanya baris yang belum ada yang ditambah.
+func syncDivisions(tx *gorm.DB, divisions []domain.Division, sajdahs []domain.Sajdah, overwrite bool) (int, error) {
+	changed := 0
+
+	var current []domain.Division
+	if err := tx.Find(&current).Error; err != nil {
+		return 0, err
+	}
+
+	existing := make(map[string]domain.Division, len(current))
+	for _, d := range current {
+		existing[d.Type+":"+fmt.Sprint(d.Number)] = d
+	}
+
+	var upserts []domain.Division
+	for _, d := range divisions {
+		key := d.Type + ":" + fmt.Sprint(d.Number)
+		old, ok := existing[key]
+		delete(existing, key)
+		if ok && (!overwrite || divisionEqual(old, d)) {
+			continue
+		}
+		upserts = append(upserts, d)
+	}
+
+	if len(upserts) > 0 {
+		if err := tx.Clauses(clause.OnConflict{UpdateAll: true}).CreateInBatches(upserts, 200).Error; err != nil {
+			return 0, fmt.Errorf("upsert divisions: %w", err)
+		}
+		changed += len(upserts)
+	}
+
+	if overwrite {
+		for _, stale := range existing {
+			if err := tx.Where("type = ? AND number = ?", stale.Type, stale.Number).Delete(&domain.Division{}).Error; err != nil {
+				return 0, fmt.Errorf("delete division %s %d: %w", stale.Type, stale.Number, err)
+			}
+			changed++
+		}
+	}
+
+	var currentSajdahs []domain.Sajdah
+	if err := tx.Find(&currentSajdahs).Error; err != nil {
+		return 0, err
+	}
+
+	existingSajdahs := make(map[int]domain.Sajdah, len(currentSajdahs))
+	for _, s := range currentSajdahs {
+		existingSajdahs[s.Number] = s
+	}
+
+	for _, s := range sajdahs {
+		old, ok := existingSajdahs[s.Number]
+		delete(existingSajdahs, s.Number)
+		if ok && (!overwrite || old == s) {
+			continue
+		}
+		if err := tx.Clauses(clause.OnConflict{UpdateAll: true}).Create(&s).Error; err != nil {
+			return 0, fmt.Errorf("upsert sajdah %d: %w", s.Number, err)
+		}
+		changed++
+	}
+
+	if overwrite {
+		for number := range existingSajdahs {
+			if err := tx.Where("number = ?", number).Delete(&domain.Sajdah{}).Error; err != nil {
+				return 0, fmt.Errorf("delete sajdah %d: %w", number, err)
+			}
+			changed++
+		}
+	}
+
+	return changed, nil
+}
+
+func divisionEqual(a, b domain.Division) bool {
+	return a.StartSurah == b.StartSurah && a.StartAyah == b.StartAyah &&
+		a.EndSurah == b.EndSurah && a.EndAyah == b.EndAyah &&
+		a.FirstAyah == b.FirstAyah && a.LastAyah == b.LastAyah
+}
+
+// annotateAyahs mengisi kolom juz, hizb dan page di ayahs dari tabel divisions
+func annotateAyahs(tx *gorm.DB) (int64, error) {
+	res := tx.Exec(`UPDATE ayahs a
+		SET juz = j.number, hizb = h.number, page = p.number
+		FROM divisions j, divisions h, divisions p
+		WHERE j.type = ? AND a.number_in_quran BETWEEN j.first_ayah AND j.last_ayah
+		  AND h.type = ? AND a.number_in_quran BETWEEN h.first_ayah AND h.last_ayah
+		  AND p.type = ? AND a.number_in_quran BETWEEN p.first_ayah AND p.last_ayah
+		  AND (a.juz, a.hizb, a.page) IS DISTINCT FROM (j.number, h.number, p.number)`,
+		domain.DivisionJuz, domain.DivisionHizb, domain.DivisionPage)
+	return res.RowsAffected, res.Error
+}
+
+// encodeSeedDivisions menulis ulang divisions.json dari isi database (dipakai dataset export)
+func encodeSeedDivisions(divisions []domain.Division, sajdahs []domain.Sajdah) []byte {
+	starts := make(map[string][][2]int)
+	sort.Slice(divisions, func(i, j int) bool {
+		if divisions[i].Type != divisions[j].Type {
+			return divisions[i].Type < divisions[j].Type
+		}
+		return divisions[i].Number < divisions[j].Number
+	})
+	for _, d := range divisions {
+		starts[d.Type] = append(starts[d.Type], [2]int{d.StartSurah, d.StartAyah})
+	}
+
+	var buf bytes.Buffer
+	buf.WriteString("{\r\n")
+
+	for _, group := range []struct {
+		key     string
+		perLine int
+	}{
+		{domain.DivisionJuz, 10},
+		{domain.DivisionRub, 8}, // 8 rub' = 1 juz per baris
+		{domain.DivisionManzil, 10},
+		{domain.DivisionRuku, 10},
+	} {
+		positions := starts[group.key]
+		fmt.Fprintf(&buf, "  %q: [\r\n", group.key)
+
+		var lines []string
+		for i := 0; i < len(positions); i += group.perLine {
+			end := min(i+group.perLine, len(positions))
+			items := make([]string, 0, end-i)
+			for _, pos := range positions[i:end] {
+				items = append(items, fmt.Sprintf("[%d, %d]", pos[0], pos[1]))
+			}
+			lines = append(lines, "    "+strings.Join(items, ", "))
+		}
+		if len(lines) > 0 {
+			buf.WriteString(strings.Join(lines, ",\r\n") + "\r\n")
+		}
+		buf.WriteString("  ],\r\n")
+	}
+
+	sort.Slice(sajdahs, func(i, j int) bool { return sajdahs[i].Number < sajdahs[j].Number })
+
+	buf.WriteString("  \"sajdah\": [\r\n")
+	lines := make([]string, 0, len(sajdahs))
+	for _, s := range sajdahs {
+		lines = append(lines, fmt.Sprintf("    { \"surah\": %d, \"ayah\": %d, \"type\": %q, \"hanafi\": %q, \"maliki\": %q, \"shafii\": %q, \"hanbali\": %q }",
+			s.SurahID, s.AyahNumber, s.Type, s.Madhhab.Hanafi, s.Madhhab.Maliki, s.Madhhab.Shafii, s.Madhhab.Hanbali))
+	}
+	if len(lines) > 0 {
+		buf.WriteString(strings.Join(lines, ",\r\n") + "\r\n")
+	}
+	buf.WriteString("  ]\r\n}")
+
+	return buf.Bytes()
+}
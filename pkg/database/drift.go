@@ -0,0 +1,423 @@
+package database
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+	"gorm.io/gorm/schema"
+
+	"khalif-alquran/internal/domain"
+	"khalif-alquran/pkg/logger"
+
+)
+
+// Skema sementara tempat semua migrasi dijalankan ulang untuk mendapatkan katalog "seharusnya".
+// Selalu di-rollback, jadi tidak pernah tersisa di database.
+const driftScratchSchema = "schema_drift_check"
+
+const (
+	DriftError   = "error"
+	DriftWarning = "warning"
+)
+
+// SchemaModels adalah struct domain yang dipetakan ke tabel hasil migrasi SQL
+var SchemaModels = []interface{}{
+	&domain.Surah{},
+	&domain.SurahAlias{},
+	&domain.Ayah{},
+	&domain.Bookmark{},
+	&domain.Division{},
+	&domain.Sajdah{},
+	&domain.MushafLayout{},
+	&domain.MushafPage{},
+	&domain.MushafLine{},
+	&domain.Translation{},
+	&domain.AyahTranslation{},
+	&domain.TafsirEdition{},
+	&domain.TafsirEntry{},
+	&domain.Word{},
+	&domain.MorphologySegment{},
+	&domain.AyahScript{},
+	&domain.Riwayah{},
+	&domain.RiwayahMapping{},
+	&domain.RiwayahAyah{},
+	&domain.TajwidRuleDefinition{},
+	&domain.TajwidAnnotation{},
+}
+
+var errDriftRollback = errors.New("drift check rollback")
+
+type DriftIssue struct {
+	Severity string `json:"severity"`
+	Source   string `json:"source"` // migrations | model
+	Table    string `json:"table,omitempty"`
+	Column   string `json:"column,omitempty"`
+	Message  string `json:"message"`
+}
+
+type DriftReport struct {
+	Issues []DriftIssue `json:"issues"`
+}
+
+func (r *DriftReport) HasErrors() bool {
+	for _, issue := range r.Issues {
+		if issue.Severity == DriftError {
+			return true
+		}
+	}
+	return false
+}
+
+func (r *DriftReport) add(severity, source, table, column, format string, args ...interface{}) {
+	r.Issues = append(r.Issues, DriftIssue{
+		Severity: severity,
+		Source:   source,
+		Table:    table,
+		Column:   column,
+		Message:  fmt.Sprintf(format, args...),
+	})
+}
+
+type catalogColumn struct {
+	TableName              string
+	ColumnName             string
+	DataType               string
+	CharacterMaximumLength *int
+	IsNullable             string
+	ColumnDefault          *string
+	IsGenerated            string // ALWAYS untuk kolom generated, misal tsvector pencarian
+}
+
+func (c catalogColumn) describe() string {
+	desc := c.DataType
+	if c.CharacterMaximumLength != nil {
+		desc = fmt.Sprintf("%s(%d)", desc, *c.CharacterMaximumLength)
+	}
+	if c.IsNullable == "NO" {
+		desc += " NOT NULL"
+	}
+	if c.ColumnDefault != nil {
+		desc += " DEFAULT " + *c.ColumnDefault
+	}
+	return desc
+}
+
+type catalog struct {
+	columns     map[string]map[string]catalogColumn
+	constraints map[string][]string
+}
+
+// CheckSchemaDrift membandingkan katalog Postgres yang sedang berjalan dengan
+// (1) hasil menjalankan seluruh migrasi yang di-embed, dan (2) struct domain GORM.
+func CheckSchemaDrift(db *gorm.DB) (*DriftReport, error) {
+	report := &DriftReport{}
+
+	m := NewMigrator(db)
+	statuses, err := m.Status()
+	if err != nil {
+		return nil, err
+	}
+	for _, s := range statuses {
+		switch {
+		case s.Missing:
+			report.add(DriftWarning, "migrations", "", "", "migration %03d_%s is applied but not embedded in this binary", s.Version, s.Name)
+		case s.Modified:
+			report.add(DriftError, "migrations", "", "", "migration %03d_%s was modified after it was applied", s.Version, s.Name)
+		case !s.Applied:
+			report.add(DriftError, "migrations", "", "", "migration %03d_%s is pending", s.Version, s.Name)
+		}
+	}
+
+	live, err := readCatalog(db)
+	if err != nil {
+		return nil, err
+	}
+
+	migrations, err := m.Load()
+	if err != nil {
+		return nil, err
+	}
+
+	expected, err := expectedCatalog(db, migrations)
+	if err != nil {
+		return nil, fmt.Errorf("replay migrations in scratch schema: %w", err)
+	}
+
+	compareCatalogs(report, expected, live)
+
+	for _, model := range SchemaModels {
+		if err := compareModel(report, db, model, live); err != nil {
+			return nil, err
+		}
+	}
+
+	return report, nil
+}
+
+// ReportSchemaDrift dipanggil saat boot: mencatat semua perbedaan ke log,
+// dan menghentikan aplikasi jika strict mode aktif dan ada error
+func ReportSchemaDrift(db *gorm.DB, strict bool) {
+	report, err := CheckSchemaDrift(db)
+	if err != nil {
+		if strict {
+			logger.Fatal("Failed to check schema drift", zap.Error(err))
+		}
+		logger.Error("Failed to check schema drift", zap.Error(err))
+		return
+	}
+
+	for _, issue := range report.Issues {
+		fields := []zap.Field{
+			zap.String("source", issue.Source),
+			zap.String("table", issue.Table),
+			zap.String("column", issue.Column),
+		}
+		if issue.Severity == DriftError {
+			logger.Error("Schema drift: "+issue.Message, fields...)
+		} else {
+			logger.Warn("Schema drift: "+issue.Message, fields...)
+		}
+	}
+
+	if report.HasErrors() && strict {
+		logger.Fatal("Schema drift detected in strict mode, refusing to start")
+	}
+
+	if len(report.Issues) == 0 {
+		logger.Info("Database schema matches migrations and domain models")
+	}
+}
+
+func readCatalog(tx *gorm.DB) (*catalog, error) {
+	var columns []catalogColumn
+	err := tx.Raw(`SELECT table_name, column_name, data_type, character_maximum_length, is_nullable, column_default, is_generated
+		FROM information_schema.columns
+		WHERE table_schema = current_schema() AND table_name <> 'schema_migrations'
+		ORDER BY table_name, ordinal_position`).Scan(&columns).Error
+	if err != nil {
+		return nil, err
+	}
+
+	var constraints []struct {
+		TableName  string
+		Definition string
+	}
+	err = tx.Raw(`SELECT c.conrelid::regclass::text AS table_name, pg_get_constraintdef(c.oid) AS definition
+		FROM pg_constraint c
+		JOIN pg_namespace n ON n.oid = c.connamespace
+		WHERE n.nspname = current_schema() AND c.contype IN ('p', 'u', 'f')
+		  AND c.conrelid::regclass::text <> 'schema_migrations'`).Scan(&constraints).Error
+	if err != nil {
+		return nil, err
+	}
+
+	cat := &catalog{
+		columns:     make(map[string]map[string]catalogColumn),
+		constraints: make(map[string][]string),
+	}
+	for _, col := range columns {
+		if cat.columns[col.TableName] == nil {
+			cat.columns[col.TableName] = make(map[string]catalogColumn)
+		}
+		cat.columns[col.TableName][col.ColumnName] = col
+	}
+	for _, con := range constraints {
+		cat.constraints[con.TableName] = append(cat.constraints[con.TableName], con.Definition)
+	}
+	for table := range cat.constraints {
+		sort.Strings(cat.constraints[table])
+	}
+
+	return cat, nil
+}
+
+// expectedCatalog menjalankan semua migrasi di skema sementara dalam satu transaksi
+// yang selalu di-rollback, lalu membaca katalognya
+func expectedCatalog(db *gorm.DB, migrations []Migration) (*catalog, error) {
+	var cat *catalog
+
+	err := db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Exec("CREATE SCHEMA " + driftScratchSchema).Error; err != nil {
+			return err
+		}
+		// public tetap di path agar objek extension (misal operator class pg_trgm) bisa ditemukan;
+		// tabel baru tetap dibuat di skema sementara karena skema itu yang pertama
+		if err := tx.Exec("SET LOCAL search_path TO " + driftScratchSchema + ", public").Error; err != nil {
+			return err
+		}
+
+		for _, mig := range migrations {
+			if err := execStatements(tx, mig.SQL); err != nil {
+				return fmt.Errorf("migration %s: %w", mig.File, err)
+			}
+		}
+
+		var err error
+		cat, err = readCatalog(tx)
+		if err != nil {
+			return err
+		}
+		return errDriftRollback
+	})
+
+	if err != nil && !errors.Is(err, errDriftRollback) {
+		return nil, err
+	}
+	return cat, nil
+}
+
+func compareCatalogs(report *DriftReport, expected, live *catalog) {
+	for _, table := range sortedKeys(expected.columns) {
+		liveColumns, ok := live.columns[table]
+		if !ok {
+			report.add(DriftError, "migrations", table, "", "table is defined by migrations but missing in database")
+			continue
+		}
+
+		for _, name := range sortedKeys(expected.columns[table]) {
+			want := expected.columns[table][name]
+			got, ok := liveColumns[name]
+			if !ok {
+				report.add(DriftError, "migrations", table, name, "column is defined by migrations but missing in database")
+				continue
+			}
+			if want.describe() != got.describe() {
+				report.add(DriftError, "migrations", table, name, "column is %q in database, migrations define %q", got.describe(), want.describe())
+			}
+		}
+
+		for _, name := range sortedKeys(liveColumns) {
+			if _, ok := expected.columns[table][name]; !ok {
+				report.add(DriftWarning, "migrations", table, name, "column exists in database but not in migrations")
+			}
+		}
+
+		wantConstraints := toSet(expected.constraints[table])
+		gotConstraints := toSet(live.constraints[table])
+		for _, def := range expected.constraints[table] {
+			if !gotConstraints[def] {
+				report.add(DriftError, "migrations", table, "", "constraint %q is defined by migrations but missing in database", def)
+			}
+		}
+		for _, def := range live.constraints[table] {
+			if !wantConstraints[def] {
+				report.add(DriftWarning, "migrations", table, "", "constraint %q exists in database but not in migrations", def)
+			}
+		}
+	}
+
+	for _, table := range sortedKeys(live.columns) {
+		if _, ok := expected.columns[table]; !ok {
+			report.add(DriftWarning, "migrations", table, "", "table exists in database but not in migrations")
+		}
+	}
+}
+
+func compareModel(report *DriftReport, db *gorm.DB, model interface{}, live *catalog) error {
+	s, err := schema.Parse(model, &sync.Map{}, db.NamingStrategy)
+	if err != nil {
+		return err
+	}
+
+	liveColumns, ok := live.columns[s.Table]
+	if !ok {
+		report.add(DriftError, "model", s.Table, "", "table used by %s does not exist", s.Name)
+		return nil
+	}
+
+	mapped := make(map[string]bool)
+	for _, field := range s.Fields {
+		if field.DBName == "" || field.IgnoreMigration {
+			continue
+		}
+		mapped[field.DBName] = true
+
+		col, ok := liveColumns[field.DBName]
+		if !ok {
+			report.add(DriftError, "model", s.Table, field.DBName, "%s.%s maps to a column that does not exist", s.Name, field.Name)
+			continue
+		}
+		if !typeCompatible(field, col.DataType) {
+			report.add(DriftError, "model", s.Table, field.DBName, "%s.%s (%s) is incompatible with column type %s", s.Name, field.Name, field.DataType, col.DataType)
+		}
+	}
+
+	for _, name := range sortedKeys(liveColumns) {
+		if mapped[name] {
+			continue
+		}
+		col := liveColumns[name]
+		if col.IsGenerated == "ALWAYS" {
+			// Diisi Postgres sendiri dan hanya dibaca lewat SQL mentah, tidak perlu dipetakan
+			continue
+		}
+		if col.IsNullable == "NO" && col.ColumnDefault == nil {
+			report.add(DriftError, "model", s.Table, name, "NOT NULL column without default is not mapped by %s, inserts will fail", s.Name)
+		} else {
+			report.add(DriftWarning, "model", s.Table, name, "column is not mapped by %s", s.Name)
+		}
+	}
+
+	var modelPK []string
+	for _, field := range s.PrimaryFields {
+		modelPK = append(modelPK, field.DBName)
+	}
+	wantPK := "PRIMARY KEY (" + strings.Join(modelPK, ", ") + ")"
+	if !toSet(live.constraints[s.Table])[wantPK] {
+		report.add(DriftError, "model", s.Table, "", "%s declares %s but the table does not", s.Name, wantPK)
+	}
+
+	return nil
+}
+
+// typeCompatible memeriksa kecocokan kasar antara tipe Go (via GORM) dan tipe kolom Postgres
+func typeCompatible(field *schema.Field, pgType string) bool {
+	var allowed []string
+	switch strings.ToLower(string(field.DataType)) {
+	case string(schema.Int), string(schema.Uint):
+		allowed = []string{"smallint", "integer", "bigint"}
+	case string(schema.Float):
+		allowed = []string{"real", "double precision", "numeric"}
+	case string(schema.String), "text":
+		allowed = []string{"text", "character varying", "character"}
+	case string(schema.Bool):
+		allowed = []string{"boolean"}
+	case string(schema.Time):
+		allowed = []string{"timestamp with time zone", "timestamp without time zone", "date"}
+	case string(schema.Bytes):
+		allowed = []string{"bytea"}
+	case "json", "jsonb":
+		allowed = []string{"json", "jsonb"}
+	default:
+		return true
+	}
+
+	for _, t := range allowed {
+		if t == pgType {
+			return true
+		}
+	}
+	return false
+}
+
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+func toSet(values []string) map[string]bool {
+	set := make(map[string]bool, len(values))
+	for _, v := range values {
+		set[v] = true
+	}
+	return set
+}
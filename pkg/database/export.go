@@ -0,0 +1,518 @@
+package database
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"database/sql"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+	"unicode"
+
+	"gorm.io/gorm"
+
+	"khalif-alquran/internal/domain"
+
+)
+
+const datasetManifestFile = "manifest.json"
+
+// DatasetFile adalah satu entri manifest: path relatif terhadap folder ekspor beserta checksum-nya
+type DatasetFile struct {
+	Path   string `json:"path"`
+	SHA256 string `json:"sha256"`
+	Bytes  int    `json:"bytes"`
+}
+
+// DatasetManifest ditulis sebagai manifest.json di samping file seed hasil ekspor
+type DatasetManifest struct {
+	Version    string        `json:"dataset_version"`
+	Surahs     int           `json:"surahs"`
+	TotalAyahs int           `json:"total_ayahs"`
+	Files      []DatasetFile `json:"files"`
+}
+
+// Bentuk file seed per-surah. Sengaja tidak memakai domain.Surah langsung agar kolom
+// turunan (created_at, available_ayahs, dst.) tidak ikut tertulis ke version control.
+type seedSurahFile struct {
+	Number         int            `json:"number"`
+	Name           string         `json:"name"`
+	LatinName      string         `json:"latin_name"`
+	EnglishName    string         `json:"english_name"`
+	IndonesianName string         `json:"indonesian_name"`
+	RevelationType string         `json:"revelation_type"`
+	TotalAyahs     int            `json:"ayah_count"`
+	Ayahs          []seedAyahFile `json:"ayahs"`
+}
+
+type seedAyahFile struct {
+	Number       int                 `json:"number"`
+	TextArabic   string              `json:"text_arabic"`
+	TextLatin    string              `json:"text_latin"`
+	Translation  string              `json:"translation_id"`
+	Tafsir       string              `json:"tafsir"`
+	AsbabunNuzul string              `json:"asbabun_nuzul"`
+	TajwidInfo   []domain.TajwidRule `json:"tajwid_info"`
+}
+
+// ExportDataset menulis isi database ke dir dengan layout yang sama seperti folder seeds,
+// ditambah manifest.json. Layout tersebut: quran.json, divisions.json, translations.json,
+// tajwid_rules.json, surah_aliases.json, serta folder data/, translations/, mushaf/, tafsir/,
+// words/, scripts/ dan riwayat/. Nama file per-surah diambil dari corpus seed saat ini
+// (existing) bila ada, sehingga hasil ekspor bisa langsung di-diff.
+func ExportDataset(db *gorm.DB, dir, version string, existing fs.FS) (*DatasetManifest, error) {
+	var surahs []domain.Surah
+	var divisions []domain.Division
+	var sajdahs []domain.Sajdah
+	var layouts []seedMushaf
+	var translations []domain.Translation
+	var translationTexts []domain.AyahTranslation
+	var tafsirEditions []domain.TafsirEdition
+	var tafsirRows []domain.TafsirEntry
+	var words []domain.Word
+	var scripts []domain.AyahScript
+	var riwayat []domain.Riwayah
+	var riwayahMappings []domain.RiwayahMapping
+	var riwayahTexts []domain.RiwayahAyah
+	var tajwidRules []domain.TajwidRuleDefinition
+	var surahAliases []domain.SurahAlias
+
+	// Snapshot konsisten: editor bisa saja sedang mengubah teks saat ekspor berjalan
+	err := db.Transaction(func(tx *gorm.DB) error {
+		err := tx.Preload("Ayahs", func(db *gorm.DB) *gorm.DB {
+			return db.Order("ayahs.number ASC")
+		}).Order("number ASC").Find(&surahs).Error
+		if err != nil {
+			return err
+		}
+		if err := tx.Find(&divisions).Error; err != nil {
+			return err
+		}
+		if err := tx.Find(&sajdahs).Error; err != nil {
+			return err
+		}
+		if err := tx.Find(&translations).Error; err != nil {
+			return err
+		}
+		if err := tx.Find(&translationTexts).Error; err != nil {
+			return err
+		}
+		if err := tx.Find(&tafsirEditions).Error; err != nil {
+			return err
+		}
+		if err := tx.Find(&tafsirRows).Error; err != nil {
+			return err
+		}
+		if err := tx.Find(&words).Error; err != nil {
+			return err
+		}
+		if err := tx.Find(&scripts).Error; err != nil {
+			return err
+		}
+		if err := tx.Order("code ASC").Find(&riwayat).Error; err != nil {
+			return err
+		}
+		if err := tx.Find(&riwayahMappings).Error; err != nil {
+			return err
+		}
+		if err := tx.Find(&riwayahTexts).Error; err != nil {
+			return err
+		}
+		if err := tx.Find(&tajwidRules).Error; err != nil {
+			return err
+		}
+		if err := tx.Find(&surahAliases).Error; err != nil {
+			return err
+		}
+		layouts, err = loadMushafLayouts(tx)
+		return err
+	}, &sql.TxOptions{Isolation: sql.LevelRepeatableRead, ReadOnly: true})
+	if err != nil {
+		return nil, err
+	}
+
+	if len(surahs) == 0 {
+		return nil, fmt.Errorf("database contains no surahs, nothing to export")
+	}
+
+	fileNames := seedFileNames(existing)
+
+	if err := os.MkdirAll(filepath.Join(dir, path.Dir(seedDataPattern)), 0o755); err != nil {
+		return nil, err
+	}
+
+	manifest := &DatasetManifest{Surahs: len(surahs)}
+
+	catalog, err := encodeSeedCatalog(surahs)
+	if err != nil {
+		return nil, err
+	}
+	if err := writeDatasetFile(dir, seedCatalogFile, catalog, manifest); err != nil {
+		return nil, err
+	}
+
+	if len(divisions) > 0 {
+		if err := writeDatasetFile(dir, seedDivisionFile, encodeSeedDivisions(divisions, sajdahs), manifest); err != nil {
+			return nil, err
+		}
+	}
+
+	if len(translations) > 0 {
+		catalog, err := encodeSeedTranslationCatalog(translations)
+		if err != nil {
+			return nil, err
+		}
+		if err := writeDatasetFile(dir, seedTranslationFile, catalog, manifest); err != nil {
+			return nil, err
+		}
+
+		byEdition := make(map[string][]domain.AyahTranslation)
+		for _, row := range translationTexts {
+			byEdition[row.Edition] = append(byEdition[row.Edition], row)
+		}
+		if len(byEdition) > 0 {
+			if err := os.MkdirAll(filepath.Join(dir, path.Dir(seedTranslationPattern)), 0o755); err != nil {
+				return nil, err
+			}
+		}
+		for _, t := range translations {
+			rows, ok := byEdition[t.Code]
+			if !ok {
+				continue
+			}
+			name := path.Join(path.Dir(seedTranslationPattern), t.Code+".txt")
+			if err := writeDatasetFile(dir, name, encodeSeedTranslationText(rows), manifest); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	if len(tafsirEditions) > 0 {
+		byEdition := make(map[string][]domain.TafsirEntry)
+		for _, e := range tafsirRows {
+			byEdition[e.Edition] = append(byEdition[e.Edition], e)
+		}
+
+		if err := os.MkdirAll(filepath.Join(dir, path.Dir(seedTafsirPattern)), 0o755); err != nil {
+			return nil, err
+		}
+		for _, edition := range tafsirEditions {
+			content, err := encodeSeedTafsir(edition, byEdition[edition.Code])
+			if err != nil {
+				return nil, err
+			}
+			name := path.Join(path.Dir(seedTafsirPattern), edition.Code+".json")
+			if err := writeDatasetFile(dir, name, content, manifest); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	if len(words) > 0 {
+		bySurah := make(map[int][]domain.Word)
+		for _, w := range words {
+			bySurah[int(w.SurahID)] = append(bySurah[int(w.SurahID)], w)
+		}
+
+		if err := os.MkdirAll(filepath.Join(dir, path.Dir(seedWordPattern)), 0o755); err != nil {
+			return nil, err
+		}
+		for _, surah := range surahs {
+			rows, ok := bySurah[surah.Number]
+			if !ok {
+				continue
+			}
+			content, err := encodeSeedWords(surah.Number, rows)
+			if err != nil {
+				return nil, err
+			}
+			name := path.Join(path.Dir(seedWordPattern), fmt.Sprintf("%03d.json", surah.Number))
+			if err := writeDatasetFile(dir, name, content, manifest); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	if len(scripts) > 0 {
+		byScript := make(map[string][]domain.AyahScript)
+		for _, row := range scripts {
+			byScript[row.Script] = append(byScript[row.Script], row)
+		}
+
+		if err := os.MkdirAll(filepath.Join(dir, path.Dir(seedScriptPattern)), 0o755); err != nil {
+			return nil, err
+		}
+		for _, script := range []string{domain.ScriptImlaei, domain.ScriptIndopak} {
+			rows, ok := byScript[script]
+			if !ok {
+				continue
+			}
+			name := path.Join(path.Dir(seedScriptPattern), script+".txt")
+			if err := writeDatasetFile(dir, name, encodeSeedScriptText(rows), manifest); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	if len(riwayat) > 0 {
+		mappings := make(map[string][]domain.RiwayahMapping)
+		for _, m := range riwayahMappings {
+			mappings[m.Riwayah] = append(mappings[m.Riwayah], m)
+		}
+		texts := make(map[string][]domain.RiwayahAyah)
+		for _, t := range riwayahTexts {
+			texts[t.Riwayah] = append(texts[t.Riwayah], t)
+		}
+
+		if err := os.MkdirAll(filepath.Join(dir, path.Dir(seedRiwayahPattern)), 0o755); err != nil {
+			return nil, err
+		}
+		for _, r := range riwayat {
+			content, err := encodeSeedRiwayah(r, mappings[r.Code], texts[r.Code])
+			if err != nil {
+				return nil, err
+			}
+			name := path.Join(path.Dir(seedRiwayahPattern), r.Code+".json")
+			if err := writeDatasetFile(dir, name, content, manifest); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	// Anotasi tajwid tidak diekspor karena dihitung ulang dari tajwid_info saat seeding
+	if len(tajwidRules) > 0 {
+		content, err := encodeSeedTajwidRules(tajwidRules)
+		if err != nil {
+			return nil, err
+		}
+		if err := writeDatasetFile(dir, seedTajwidRuleFile, content, manifest); err != nil {
+			return nil, err
+		}
+	}
+
+	aliasContent, err := encodeSeedSurahAliases(surahs, surahAliases)
+	if err != nil {
+		return nil, err
+	}
+	if aliasContent != nil {
+		if err := writeDatasetFile(dir, seedSurahAliasFile, aliasContent, manifest); err != nil {
+			return nil, err
+		}
+	}
+
+	if len(layouts) > 0 {
+		counts := make(map[int]int, len(surahs))
+		for _, surah := range surahs {
+			counts[surah.Number] = surah.TotalAyahs
+		}
+		idx := newAyahIndex(counts)
+
+		if err := os.MkdirAll(filepath.Join(dir, path.Dir(seedMushafPattern)), 0o755); err != nil {
+			return nil, err
+		}
+		for _, layout := range layouts {
+			content, err := encodeSeedMushaf(layout, idx)
+			if err != nil {
+				return nil, err
+			}
+			name := path.Join(path.Dir(seedMushafPattern), layout.Layout.Code+".json")
+			if err := writeDatasetFile(dir, name, content, manifest); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	for _, surah := range surahs {
+		if len(surah.Ayahs) == 0 {
+			continue
+		}
+
+		content, err := encodeSeedSurah(surah)
+		if err != nil {
+			return nil, fmt.Errorf("surah %d: %w", surah.Number, err)
+		}
+
+		name, ok := fileNames[surah.Number]
+		if !ok {
+			name = path.Join(path.Dir(seedDataPattern), seedFileSlug(surah)+".json")
+		}
+		if err := writeDatasetFile(dir, name, content, manifest); err != nil {
+			return nil, err
+		}
+		manifest.TotalAyahs += len(surah.Ayahs)
+	}
+
+	sort.Slice(manifest.Files, func(i, j int) bool {
+		return manifest.Files[i].Path < manifest.Files[j].Path
+	})
+
+	manifest.Version = version
+	if manifest.Version == "" {
+		manifest.Version = datasetDigest(manifest.Files)
+	}
+
+	content, err := encodeSeedJSON(manifest, "  ")
+	if err != nil {
+		return nil, err
+	}
+	if err := os.WriteFile(filepath.Join(dir, datasetManifestFile), content, 0o644); err != nil {
+		return nil, err
+	}
+
+	return manifest, nil
+}
+
+func writeDatasetFile(dir, name string, content []byte, manifest *DatasetManifest) error {
+	if err := os.WriteFile(filepath.Join(dir, filepath.FromSlash(name)), content, 0o644); err != nil {
+		return err
+	}
+
+	sum := sha256.Sum256(content)
+	manifest.Files = append(manifest.Files, DatasetFile{
+		Path:   name,
+		SHA256: hex.EncodeToString(sum[:]),
+		Bytes:  len(content),
+	})
+	return nil
+}
+
+// datasetDigest menjadi versi default: berubah hanya jika isi salah satu file berubah
+func datasetDigest(files []DatasetFile) string {
+	h := sha256.New()
+	for _, f := range files {
+		fmt.Fprintf(h, "%s  %s\n", f.SHA256, f.Path)
+	}
+	return hex.EncodeToString(h.Sum(nil))[:12]
+}
+
+// seedFileNames memetakan nomor surah ke path file seed yang sudah ada (misal data/alfatihah.json)
+func seedFileNames(source fs.FS) map[int]string {
+	names := make(map[int]string)
+	if source == nil {
+		return names
+	}
+
+	files, err := fs.Glob(source, seedDataPattern)
+	if err != nil {
+		return names
+	}
+
+	for _, filename := range files {
+		data, err := fs.ReadFile(source, filename)
+		if err != nil {
+			continue
+		}
+		var header struct {
+			Number int `json:"number"`
+		}
+		if json.Unmarshal(data, &header) == nil && header.Number > 0 {
+			names[header.Number] = filename
+		}
+	}
+	return names
+}
+
+// seedFileSlug membuat nama file dari nama latin: "Ali 'Imran" -> "aliimran"
+func seedFileSlug(surah domain.Surah) string {
+	var b strings.Builder
+	for _, r := range strings.ToLower(surah.LatinName) {
+		if r < unicode.MaxASCII && (unicode.IsLetter(r) || unicode.IsDigit(r)) {
+			b.WriteRune(r)
+		}
+	}
+	if b.Len() == 0 {
+		return fmt.Sprintf("%03d", surah.Number)
+	}
+	return b.String()
+}
+
+func encodeSeedSurah(surah domain.Surah) ([]byte, error) {
+	file := seedSurahFile{
+		Number:         surah.Number,
+		Name:           surah.Name,
+		LatinName:      surah.LatinName,
+		EnglishName:    surah.EnglishName,
+		IndonesianName: surah.IndonesianName,
+		RevelationType: surah.RevelationType,
+		TotalAyahs:     surah.TotalAyahs,
+		Ayahs:          make([]seedAyahFile, 0, len(surah.Ayahs)),
+	}
+
+	for _, ayah := range surah.Ayahs {
+		tajwid := []domain.TajwidRule(ayah.TajwidInfo)
+		if tajwid == nil {
+			tajwid = []domain.TajwidRule{}
+		}
+		file.Ayahs = append(file.Ayahs, seedAyahFile{
+			Number:       ayah.Number,
+			TextArabic:   ayah.TextArabic,
+			TextLatin:    ayah.TextLatin,
+			Translation:  ayah.Translation,
+			Tafsir:       ayah.Tafsir,
+			AsbabunNuzul: ayah.AsbabunNuzul,
+			TajwidInfo:   tajwid,
+		})
+	}
+
+	return encodeSeedJSON(file, "    ")
+}
+
+// encodeSeedCatalog menulis quran.json dengan satu surah per baris, sama seperti file aslinya
+func encodeSeedCatalog(surahs []domain.Surah) ([]byte, error) {
+	var buf bytes.Buffer
+	buf.WriteString("{\r\n  \"surahs\": [\r\n")
+
+	for i, surah := range surahs {
+		fields := []struct {
+			key   string
+			value interface{}
+		}{
+			{"number", surah.Number},
+			{"name", surah.Name},
+			{"latin_name", surah.LatinName},
+			{"english_name", surah.EnglishName},
+			{"indonesian_name", surah.IndonesianName},
+			{"revelation_type", surah.RevelationType},
+			{"ayah_count", surah.TotalAyahs},
+		}
+
+		parts := make([]string, 0, len(fields))
+		for _, f := range fields {
+			value, err := encodeSeedJSON(f.value, "")
+			if err != nil {
+				return nil, err
+			}
+			parts = append(parts, fmt.Sprintf("%q: %s", f.key, value))
+		}
+
+		buf.WriteString("    { " + strings.Join(parts, ", ") + " }")
+		if i < len(surahs)-1 {
+			buf.WriteString(",")
+		}
+		buf.WriteString("\r\n")
+	}
+
+	buf.WriteString("  ]\r\n}")
+	return buf.Bytes(), nil
+}
+
+// encodeSeedJSON mengikuti gaya file seed yang ada: teks Arab tidak di-escape,
+// baris diakhiri CRLF dan tanpa newline di akhir file
+func encodeSeedJSON(v interface{}, indent string) ([]byte, error) {
+	var buf bytes.Buffer
+	enc := json.NewEncoder(&buf)
+	enc.SetEscapeHTML(false)
+	enc.SetIndent("", indent)
+	if err := enc.Encode(v); err != nil {
+		return nil, err
+	}
+
+	out := bytes.TrimRight(buf.Bytes(), "\n")
+	return bytes.ReplaceAll(out, []byte("\n"), []byte("\r\n")), nil
+}
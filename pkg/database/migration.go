@@ -1,8 +1,18 @@
 package database
 
 import (
+	"crypto/sha256"
 	"embed"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io/fs"
+	"path"
+	"regexp"
+	"sort"
+	"strconv"
 	"strings"
+	"time"
 
 	"go.uber.org/zap"
 	"gorm.io/gorm"
@@ -11,19 +21,420 @@ import (
 
 )
 
-// Pastikan folder pkg/database/schema/ dan file 001_init_schema.sql ada
+// Semua file migrasi berada di pkg/database/schema/ dengan format
+// NNN_nama.up.sql dan pasangannya NNN_nama.down.sql (NNN_nama.sql dianggap up saja)
 //go:embed schema/*.sql
 var schemaFS embed.FS
 
-func RunMigrations(db *gorm.DB) {
-	// Membaca file migrasi SQL dari embed system
-	content, err := schemaFS.ReadFile("schema/001_init_schema.sql")
+// Kunci advisory lock Postgres agar dua instance tidak menjalankan migrasi bersamaan
+const migrationLockKey = 7_283_114
+
+var (
+	ErrMigrationChecksumMismatch = errors.New("applied migration file has been modified")
+	ErrMigrationDuplicateVersion = errors.New("duplicate migration version")
+	ErrMigrationIrreversible     = errors.New("migration has no down file")
+	ErrMigrationMissing          = errors.New("applied migration is not embedded in this binary")
+)
+
+var migrationFilePattern = regexp.MustCompile(`^(\d+)_([a-z0-9_]+?)(\.up|\.down)?\.sql$`)
+
+// Migration merepresentasikan satu file migrasi yang di-embed ke binary
+type Migration struct {
+	Version  int
+	Name     string
+	File     string
+	Checksum string
+	SQL      string
+	DownFile string
+	DownSQL  string
+}
+
+// MigrationStatus dipakai oleh perintah `migrate status`
+type MigrationStatus struct {
+	Version    int
+	Name       string
+	Applied    bool
+	AppliedAt  time.Time
+	Modified   bool
+	Missing    bool
+	Reversible bool
+}
+
+// AppliedMigration adalah baris pada tabel tracking schema_migrations
+type AppliedMigration struct {
+	Version   int       `gorm:"primaryKey;autoIncrement:false"`
+	Name      string
+	Checksum  string
+	AppliedAt time.Time
+}
+
+func (AppliedMigration) TableName() string {
+	return "schema_migrations"
+}
+
+type Migrator struct {
+	db     *gorm.DB
+	source fs.FS
+	dir    string
+}
+
+func NewMigrator(db *gorm.DB) *Migrator {
+	return &Migrator{db: db, source: schemaFS, dir: "schema"}
+}
+
+// Load membaca seluruh file migrasi, memasangkan up/down, dan mengurutkannya berdasarkan versi
+func (m *Migrator) Load() ([]Migration, error) {
+	entries, err := fs.ReadDir(m.source, m.dir)
+	if err != nil {
+		return nil, err
+	}
+
+	byVersion := make(map[int]*Migration)
+
+	for _, entry := range entries {
+		if entry.IsDir() {
+			continue
+		}
+
+		match := migrationFilePattern.FindStringSubmatch(entry.Name())
+		if match == nil {
+			return nil, fmt.Errorf("invalid migration file name %q (expected NNN_name.up.sql / NNN_name.down.sql)", entry.Name())
+		}
+
+		version, _ := strconv.Atoi(match[1])
+		mig, ok := byVersion[version]
+		if !ok {
+			mig = &Migration{Version: version, Name: match[2]}
+			byVersion[version] = mig
+		} else if mig.Name != match[2] {
+			return nil, fmt.Errorf("%w: %d (%s, %s)", ErrMigrationDuplicateVersion, version, mig.Name, match[2])
+		}
+
+		content, err := fs.ReadFile(m.source, path.Join(m.dir, entry.Name()))
+		if err != nil {
+			return nil, err
+		}
+
+		if match[3] == ".down" {
+			if mig.DownFile != "" {
+				return nil, fmt.Errorf("%w: %d (%s, %s)", ErrMigrationDuplicateVersion, version, mig.DownFile, entry.Name())
+			}
+			mig.DownFile = entry.Name()
+			mig.DownSQL = string(content)
+			continue
+		}
+
+		if mig.File != "" {
+			return nil, fmt.Errorf("%w: %d (%s, %s)", ErrMigrationDuplicateVersion, version, mig.File, entry.Name())
+		}
+
+		// Checksum hanya dihitung dari file up, karena itulah yang pernah dijalankan
+		sum := sha256.Sum256(content)
+		mig.File = entry.Name()
+		mig.Checksum = hex.EncodeToString(sum[:])
+		mig.SQL = string(content)
+	}
+
+	migrations := make([]Migration, 0, len(byVersion))
+	for _, mig := range byVersion {
+		if mig.File == "" {
+			return nil, fmt.Errorf("migration %d has a down file (%s) but no up file", mig.Version, mig.DownFile)
+		}
+		migrations = append(migrations, *mig)
+	}
+
+	sort.Slice(migrations, func(i, j int) bool {
+		return migrations[i].Version < migrations[j].Version
+	})
+
+	return migrations, nil
+}
+
+// Applied mengembalikan migrasi yang sudah tercatat, di-index berdasarkan versi
+func (m *Migrator) Applied() (map[int]AppliedMigration, error) {
+	if err := m.ensureTrackingTable(); err != nil {
+		return nil, err
+	}
+
+	var rows []AppliedMigration
+	if err := m.db.Order("version ASC").Find(&rows).Error; err != nil {
+		return nil, err
+	}
+
+	applied := make(map[int]AppliedMigration, len(rows))
+	for _, row := range rows {
+		applied[row.Version] = row
+	}
+	return applied, nil
+}
+
+// Verify memastikan file migrasi yang sudah dijalankan tidak diubah setelahnya
+func (m *Migrator) Verify(migrations []Migration, applied map[int]AppliedMigration) error {
+	for _, mig := range migrations {
+		row, ok := applied[mig.Version]
+		if !ok {
+			continue
+		}
+		if row.Checksum != mig.Checksum {
+			return fmt.Errorf("%w: %s (recorded %s, embedded %s)",
+				ErrMigrationChecksumMismatch, mig.File, shortChecksum(row.Checksum), shortChecksum(mig.Checksum))
+		}
+	}
+	return nil
+}
+
+// Up menjalankan semua migrasi yang belum tercatat, masing-masing dalam satu transaksi
+func (m *Migrator) Up() (int, error) {
+	return m.UpN(0)
+}
+
+// UpN menjalankan maksimal n migrasi yang belum tercatat (n <= 0 berarti semuanya)
+func (m *Migrator) UpN(n int) (int, error) {
+	migrations, err := m.Load()
 	if err != nil {
-		logger.Fatal("Failed to read migration file from embed", zap.Error(err))
+		return 0, err
 	}
 
-	// Memisahkan query berdasarkan separator khusus
-	blocks := strings.Split(string(content), "--SEPARATOR--")
+	applied, err := m.Applied()
+	if err != nil {
+		return 0, err
+	}
+
+	if err := m.Verify(migrations, applied); err != nil {
+		return 0, err
+	}
+
+	count := 0
+	for _, mig := range migrations {
+		if n > 0 && count >= n {
+			break
+		}
+		if _, ok := applied[mig.Version]; ok {
+			continue
+		}
+
+		ran, err := m.apply(mig)
+		if err != nil {
+			return count, err
+		}
+		if ran {
+			count++
+			logger.Info("Migration applied", zap.Int("version", mig.Version), zap.String("file", mig.File))
+		}
+	}
+
+	return count, nil
+}
+
+func (m *Migrator) apply(mig Migration) (bool, error) {
+	ran := false
+
+	err := m.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Exec("SELECT pg_advisory_xact_lock(?)", migrationLockKey).Error; err != nil {
+			return err
+		}
+
+		// Cek ulang setelah lock, instance lain mungkin sudah menjalankan versi ini
+		var exists int64
+		if err := tx.Model(&AppliedMigration{}).Where("version = ?", mig.Version).Count(&exists).Error; err != nil {
+			return err
+		}
+		if exists > 0 {
+			return nil
+		}
+
+		if err := execStatements(tx, mig.SQL); err != nil {
+			return fmt.Errorf("migration %s: %w", mig.File, err)
+		}
+
+		ran = true
+		return tx.Create(&AppliedMigration{
+			Version:   mig.Version,
+			Name:      mig.Name,
+			Checksum:  mig.Checksum,
+			AppliedAt: time.Now(),
+		}).Error
+	})
+
+	return ran, err
+}
+
+// Down me-rollback n migrasi terakhir yang sudah tercatat, dari versi tertinggi
+func (m *Migrator) Down(n int) (int, error) {
+	if n <= 0 {
+		return 0, fmt.Errorf("down requires a positive step count, got %d", n)
+	}
+
+	migrations, err := m.Load()
+	if err != nil {
+		return 0, err
+	}
+
+	applied, err := m.Applied()
+	if err != nil {
+		return 0, err
+	}
+
+	if err := m.Verify(migrations, applied); err != nil {
+		return 0, err
+	}
+
+	byVersion := make(map[int]Migration, len(migrations))
+	for _, mig := range migrations {
+		byVersion[mig.Version] = mig
+	}
+
+	versions := make([]int, 0, len(applied))
+	for version := range applied {
+		versions = append(versions, version)
+	}
+	sort.Sort(sort.Reverse(sort.IntSlice(versions)))
+
+	count := 0
+	for _, version := range versions {
+		if count >= n {
+			break
+		}
+
+		mig, ok := byVersion[version]
+		if !ok {
+			return count, fmt.Errorf("%w: version %d", ErrMigrationMissing, version)
+		}
+		if mig.DownSQL == "" {
+			return count, fmt.Errorf("%w: %s", ErrMigrationIrreversible, mig.File)
+		}
+
+		if err := m.revert(mig); err != nil {
+			return count, err
+		}
+		count++
+		logger.Info("Migration rolled back", zap.Int("version", mig.Version), zap.String("file", mig.DownFile))
+	}
+
+	return count, nil
+}
+
+func (m *Migrator) revert(mig Migration) error {
+	return m.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Exec("SELECT pg_advisory_xact_lock(?)", migrationLockKey).Error; err != nil {
+			return err
+		}
+
+		if err := execStatements(tx, mig.DownSQL); err != nil {
+			return fmt.Errorf("migration %s: %w", mig.DownFile, err)
+		}
+
+		return tx.Where("version = ?", mig.Version).Delete(&AppliedMigration{}).Error
+	})
+}
+
+// Status menggabungkan file yang di-embed dengan isi tabel tracking
+func (m *Migrator) Status() ([]MigrationStatus, error) {
+	migrations, err := m.Load()
+	if err != nil {
+		return nil, err
+	}
+
+	applied, err := m.Applied()
+	if err != nil {
+		return nil, err
+	}
+
+	statuses := make([]MigrationStatus, 0, len(migrations))
+	for _, mig := range migrations {
+		status := MigrationStatus{
+			Version:    mig.Version,
+			Name:       mig.Name,
+			Reversible: mig.DownSQL != "",
+		}
+		if row, ok := applied[mig.Version]; ok {
+			status.Applied = true
+			status.AppliedAt = row.AppliedAt
+			status.Modified = row.Checksum != mig.Checksum
+			delete(applied, mig.Version)
+		}
+		statuses = append(statuses, status)
+	}
+
+	// Versi yang tercatat di database tapi tidak ada di binary ini (misal binary lebih lama)
+	for _, row := range applied {
+		statuses = append(statuses, MigrationStatus{
+			Version:   row.Version,
+			Name:      row.Name,
+			Applied:   true,
+			AppliedAt: row.AppliedAt,
+			Missing:   true,
+		})
+	}
+
+	sort.Slice(statuses, func(i, j int) bool {
+		return statuses[i].Version < statuses[j].Version
+	})
+
+	return statuses, nil
+}
+
+// Force menandai database berada di versi tertentu tanpa menjalankan SQL apapun.
+// Dipakai setelah perbaikan manual: versi <= version dicatat ulang dengan checksum
+// file saat ini, versi > version dihapus dari tabel tracking.
+func (m *Migrator) Force(version int) error {
+	migrations, err := m.Load()
+	if err != nil {
+		return err
+	}
+
+	if err := m.ensureTrackingTable(); err != nil {
+		return err
+	}
+
+	known := version == 0
+	for _, mig := range migrations {
+		if mig.Version == version {
+			known = true
+		}
+	}
+	if !known {
+		return fmt.Errorf("unknown migration version %d", version)
+	}
+
+	return m.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Exec("SELECT pg_advisory_xact_lock(?)", migrationLockKey).Error; err != nil {
+			return err
+		}
+
+		if err := tx.Where("version > ?", version).Delete(&AppliedMigration{}).Error; err != nil {
+			return err
+		}
+
+		for _, mig := range migrations {
+			if mig.Version > version {
+				break
+			}
+			err := tx.Exec(`INSERT INTO schema_migrations (version, name, checksum, applied_at)
+				VALUES (?, ?, ?, NOW())
+				ON CONFLICT (version) DO UPDATE SET name = EXCLUDED.name, checksum = EXCLUDED.checksum`,
+				mig.Version, mig.Name, mig.Checksum).Error
+			if err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+func (m *Migrator) ensureTrackingTable() error {
+	return m.db.Exec(`CREATE TABLE IF NOT EXISTS schema_migrations (
+		version INT PRIMARY KEY,
+		name VARCHAR(255) NOT NULL,
+		checksum CHAR(64) NOT NULL,
+		applied_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+	)`).Error
+}
+
+// execStatements memecah isi file berdasarkan separator khusus,
+// karena prepared statement tidak bisa menjalankan banyak query sekaligus
+func execStatements(tx *gorm.DB, content string) error {
+	blocks := strings.Split(content, "--SEPARATOR--")
 
 	for _, block := range blocks {
 		trimmedBlock := strings.TrimSpace(block)
@@ -31,15 +442,25 @@ func RunMigrations(db *gorm.DB) {
 			continue
 		}
 
-		if err := db.Exec(trimmedBlock).Error; err != nil {
-			logger.Fatal("Failed to execute migration block", 
-				zap.String("query_snippet", trimmedBlock[:min(len(trimmedBlock), 50)]), 
-				zap.Error(err),
-			)
+		if err := tx.Exec(trimmedBlock).Error; err != nil {
+			return fmt.Errorf("block %q: %w", trimmedBlock[:min(len(trimmedBlock), 50)], err)
 		}
 	}
+	return nil
+}
 
-	logger.Info("Database migration executed successfully")
+// RunMigrations dipanggil saat boot: gagal migrasi berarti aplikasi tidak boleh jalan
+func RunMigrations(db *gorm.DB) {
+	count, err := NewMigrator(db).Up()
+	if err != nil {
+		logger.Fatal("Failed to run database migrations", zap.Error(err))
+	}
+
+	logger.Info("Database migration executed successfully", zap.Int("applied", count))
+}
+
+func shortChecksum(sum string) string {
+	return sum[:min(len(sum), 12)]
 }
 
 func min(a, b int) int {
@@ -47,4 +468,4 @@ func min(a, b int) int {
 		return a
 	}
 	return b
-}
\ No newline at end of file
+}
@@ -0,0 +1,207 @@
+package database
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	"khalif-alquran/internal/domain"
+	"khalif-alquran/pkg/utils"
+
+)
+
+// File morfologi Quranic Arabic Corpus (quranic-corpus-morphology-0.4.txt) berisi satu segmen per baris:
+//
+//	LOCATION	FORM	TAG	FEATURES
+//	(1:1:1:1)	bi	P	PREFIX|bi+
+//	(1:1:1:2)	somi	N	STEM|POS:N|LEM:{som|ROOT:smw|M|GEN
+//
+// LOCATION adalah (surah:ayat:kata:segmen). FORM, LEM dan ROOT ditulis dalam Buckwalter.
+
+type MorphologyImportReport struct {
+	Segments  int `json:"segments"`
+	Inserted  int `json:"inserted"`
+	Updated   int `json:"updated"`
+	Unchanged int `json:"unchanged"`
+	Deleted   int `json:"deleted"`
+	Skipped   int `json:"skipped_ayahs"` // ayat di file yang belum ada di database
+}
+
+// ParseMorphologyFile membaca file morfologi Quranic Arabic Corpus dari disk
+func ParseMorphologyFile(path string) ([]domain.MorphologySegment, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	return ParseMorphology(f)
+}
+
+func ParseMorphology(r io.Reader) ([]domain.MorphologySegment, error) {
+	var segments []domain.MorphologySegment
+
+	scanner := bufio.NewScanner(r)
+	lineNo := 0
+	for scanner.Scan() {
+		lineNo++
+		line := strings.TrimRight(scanner.Text(), "\r")
+		if line == "" || strings.HasPrefix(line, "#") || strings.HasPrefix(line, "LOCATION") {
+			continue
+		}
+
+		cols := strings.Split(line, "\t")
+		if len(cols) != 4 {
+			return nil, fmt.Errorf("line %d: expected LOCATION, FORM, TAG and FEATURES separated by tabs", lineNo)
+		}
+
+		loc, err := parseMorphologyLocation(cols[0])
+		if err != nil {
+			return nil, fmt.Errorf("line %d: %w", lineNo, err)
+		}
+
+		segment := domain.MorphologySegment{
+			SurahID:    uint(loc[0]),
+			AyahNumber: loc[1],
+			Position:   loc[2],
+			Segment:    loc[3],
+			Form:       utils.BuckwalterToArabic(cols[1]),
+			Tag:        cols[2],
+			Features:   cols[3],
+		}
+
+		features := strings.Split(cols[3], "|")
+		switch features[0] {
+		case "PREFIX":
+			segment.Kind = domain.MorphologyPrefix
+		case "STEM":
+			segment.Kind = domain.MorphologyStem
+		case "SUFFIX":
+			segment.Kind = domain.MorphologySuffix
+		default:
+			return nil, fmt.Errorf("line %d: unknown segment type %q", lineNo, features[0])
+		}
+		for _, f := range features[1:] {
+			switch {
+			case strings.HasPrefix(f, "ROOT:"):
+				segment.Root = utils.BuckwalterToArabic(strings.TrimPrefix(f, "ROOT:"))
+			case strings.HasPrefix(f, "LEM:"):
+				segment.Lemma = utils.BuckwalterToArabic(strings.TrimPrefix(f, "LEM:"))
+			}
+		}
+
+		segments = append(segments, segment)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	if len(segments) == 0 {
+		return nil, fmt.Errorf("no morphology segments found")
+	}
+	return segments, nil
+}
+
+// parseMorphologyLocation mengurai "(surah:ayat:kata:segmen)"
+func parseMorphologyLocation(s string) ([4]int, error) {
+	var loc [4]int
+
+	parts := strings.Split(strings.Trim(s, "()"), ":")
+	if len(parts) != 4 {
+		return loc, fmt.Errorf("invalid location %q", s)
+	}
+	for i, p := range parts {
+		n, err := strconv.Atoi(p)
+		if err != nil || n < 1 {
+			return loc, fmt.Errorf("invalid location %q", s)
+		}
+		loc[i] = n
+	}
+	if loc[0] > totalSurahs {
+		return loc, fmt.Errorf("invalid location %q: surah out of range", s)
+	}
+	return loc, nil
+}
+
+// ImportMorphology menyimpan segmen morfologi dalam satu transaksi. Untuk setiap ayat yang ada di file,
+// segmen di database disamakan dengan file (termasuk menghapus segmen yang tidak ada lagi).
+// Ayat yang belum ada di tabel ayahs dilewati karena foreign key.
+func ImportMorphology(db *gorm.DB, segments []domain.MorphologySegment) (*MorphologyImportReport, error) {
+	report := &MorphologyImportReport{Segments: len(segments)}
+
+	err := db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Exec("SELECT pg_advisory_xact_lock(?)", seedLockKey).Error; err != nil {
+			return err
+		}
+
+		ayahs, err := ayahKeys(tx)
+		if err != nil {
+			return err
+		}
+
+		var current []domain.MorphologySegment
+		if err := tx.Find(&current).Error; err != nil {
+			return err
+		}
+		existing := make(map[[4]int]domain.MorphologySegment, len(current))
+		for _, s := range current {
+			existing[[4]int{int(s.SurahID), s.AyahNumber, s.Position, s.Segment}] = s
+		}
+
+		covered := make(map[[2]int]bool)
+		skipped := make(map[[2]int]bool)
+		var upserts []domain.MorphologySegment
+		for _, s := range segments {
+			ayah := [2]int{int(s.SurahID), s.AyahNumber}
+			if !ayahs[ayah] {
+				skipped[ayah] = true
+				continue
+			}
+			covered[ayah] = true
+
+			key := [4]int{int(s.SurahID), s.AyahNumber, s.Position, s.Segment}
+			old, ok := existing[key]
+			delete(existing, key)
+			switch {
+			case !ok:
+				report.Inserted++
+			case old == s:
+				report.Unchanged++
+				continue
+			default:
+				report.Updated++
+			}
+			upserts = append(upserts, s)
+		}
+		report.Skipped = len(skipped)
+
+		for key := range existing {
+			if !covered[[2]int{key[0], key[1]}] {
+				continue
+			}
+			err := tx.Where("surah_id = ? AND ayah_number = ? AND position = ? AND segment = ?", key[0], key[1], key[2], key[3]).
+				Delete(&domain.MorphologySegment{}).Error
+			if err != nil {
+				return fmt.Errorf("delete segment %d:%d:%d:%d: %w", key[0], key[1], key[2], key[3], err)
+			}
+			report.Deleted++
+		}
+
+		if len(upserts) > 0 {
+			if err := tx.Clauses(clause.OnConflict{UpdateAll: true}).CreateInBatches(upserts, 1000).Error; err != nil {
+				return fmt.Errorf("upsert morphology: %w", err)
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return report, nil
+}
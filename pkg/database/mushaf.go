@@ -0,0 +1,342 @@
+package database
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io/fs"
+	"path"
+	"reflect"
+	"sort"
+	"strings"
+
+	"gorm.io/gorm"
+
+	"khalif-alquran/internal/domain"
+
+)
+
+// Satu file per layout mushaf (misal mushaf/madani.json). Seperti divisions.json, halaman
+// hanya menyimpan posisi awal; akhir halaman adalah tepat sebelum awal halaman berikutnya.
+// Data baris ("lines") opsional karena belum semua layout memilikinya.
+const seedMushafPattern = "mushaf/*.json"
+
+type seedMushafFile struct {
+	Code         string           `json:"code"`
+	Name         string           `json:"name"`
+	LinesPerPage int              `json:"lines_per_page"`
+	Pages        [][2]int         `json:"pages"`
+	Lines        []seedMushafLine `json:"lines,omitempty"`
+}
+
+// seedMushafLine: start/end adalah nomor ayat di surah, kosong untuk baris judul surah dan basmalah
+type seedMushafLine struct {
+	Page  int    `json:"page"`
+	Line  int    `json:"line"`
+	Type  string `json:"type"`
+	Surah int    `json:"surah"`
+	Start int    `json:"start,omitempty"`
+	End   int    `json:"end,omitempty"`
+}
+
+// seedMushaf adalah satu layout lengkap dengan halaman (dan barisnya) yang siap disimpan
+type seedMushaf struct {
+	Layout domain.MushafLayout
+	Pages  []domain.MushafPage
+	Starts [][2]int
+}
+
+// loadSeedMushaf membaca semua file di mushaf/. Hasilnya nil jika folder tidak ada,
+// agar SEED_DIR lama tetap bisa dipakai.
+func loadSeedMushaf(source fs.FS, counts map[int]int) ([]seedMushaf, error) {
+	files, err := fs.Glob(source, seedMushafPattern)
+	if err != nil {
+		return nil, err
+	}
+
+	idx := newAyahIndex(counts)
+
+	var layouts []seedMushaf
+	seen := make(map[string]string)
+	for _, filename := range files {
+		data, err := fs.ReadFile(source, filename)
+		if err != nil {
+			return nil, fmt.Errorf("read %s: %w", filename, err)
+		}
+
+		var file seedMushafFile
+		if err := json.Unmarshal(data, &file); err != nil {
+			return nil, fmt.Errorf("parse %s: %w", filename, err)
+		}
+
+		if file.Code == "" || file.Code != strings.TrimSuffix(path.Base(filename), ".json") {
+			return nil, fmt.Errorf("%s: code %q must match the file name", filename, file.Code)
+		}
+		if other, ok := seen[file.Code]; ok {
+			return nil, fmt.Errorf("mushaf layout %q is defined in both %s and %s", file.Code, other, filename)
+		}
+		seen[file.Code] = filename
+
+		layout, err := buildMushaf(idx, file)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", filename, err)
+		}
+		layouts = append(layouts, layout)
+	}
+
+	return layouts, nil
+}
+
+func buildMushaf(idx *ayahIndex, file seedMushafFile) (seedMushaf, error) {
+	if file.LinesPerPage < 1 {
+		return seedMushaf{}, fmt.Errorf("lines_per_page must be positive")
+	}
+
+	// Jumlah halaman berbeda antar cetakan, jadi tidak dicek terhadap DivisionTotals
+	divisions, err := buildDivisions(idx, "mushaf:"+file.Code, file.Pages)
+	if err != nil {
+		return seedMushaf{}, fmt.Errorf("pages: %w", err)
+	}
+	if len(divisions) == 0 {
+		return seedMushaf{}, fmt.Errorf("pages: list is empty")
+	}
+
+	pages := make([]domain.MushafPage, len(divisions))
+	for i, d := range divisions {
+		pages[i] = domain.MushafPage{
+			Layout:     file.Code,
+			Page:       d.Number,
+			StartSurah: d.StartSurah,
+			StartAyah:  d.StartAyah,
+			EndSurah:   d.EndSurah,
+			EndAyah:    d.EndAyah,
+			FirstAyah:  d.FirstAyah,
+			LastAyah:   d.LastAyah,
+		}
+	}
+
+	seenLines := make(map[[2]int]bool, len(file.Lines))
+	for _, item := range file.Lines {
+		if item.Page < 1 || item.Page > len(pages) {
+			return seedMushaf{}, fmt.Errorf("line %d:%d: page is outside 1..%d", item.Page, item.Line, len(pages))
+		}
+		if item.Line < 1 || item.Line > file.LinesPerPage {
+			return seedMushaf{}, fmt.Errorf("line %d:%d: line is outside 1..%d", item.Page, item.Line, file.LinesPerPage)
+		}
+		if seenLines[[2]int{item.Page, item.Line}] {
+			return seedMushaf{}, fmt.Errorf("line %d:%d: listed more than once", item.Page, item.Line)
+		}
+		seenLines[[2]int{item.Page, item.Line}] = true
+
+		page := &pages[item.Page-1]
+		line := domain.MushafLine{
+			Layout:  file.Code,
+			Page:    item.Page,
+			Line:    item.Line,
+			Type:    item.Type,
+			SurahID: uint(item.Surah),
+		}
+
+		switch item.Type {
+		case domain.MushafLineSurahName, domain.MushafLineBasmallah:
+			if _, err := idx.global([2]int{item.Surah, 1}); err != nil {
+				return seedMushaf{}, fmt.Errorf("line %d:%d: %w", item.Page, item.Line, err)
+			}
+		case domain.MushafLineAyah:
+			first, err := idx.global([2]int{item.Surah, item.Start})
+			if err != nil {
+				return seedMushaf{}, fmt.Errorf("line %d:%d: %w", item.Page, item.Line, err)
+			}
+			last, err := idx.global([2]int{item.Surah, item.End})
+			if err != nil {
+				return seedMushaf{}, fmt.Errorf("line %d:%d: %w", item.Page, item.Line, err)
+			}
+			if first > last || first < page.FirstAyah || last > page.LastAyah {
+				return seedMushaf{}, fmt.Errorf("line %d:%d: ayahs %d:%d-%d are not on this page", item.Page, item.Line, item.Surah, item.Start, item.End)
+			}
+			line.FirstAyah, line.LastAyah = &first, &last
+		default:
+			return seedMushaf{}, fmt.Errorf("line %d:%d: unknown type %q", item.Page, item.Line, item.Type)
+		}
+
+		page.Lines = append(page.Lines, line)
+	}
+
+	for i := range pages {
+		sort.Slice(pages[i].Lines, func(a, b int) bool { return pages[i].Lines[a].Line < pages[i].Lines[b].Line })
+	}
+
+	return seedMushaf{
+		Layout: domain.MushafLayout{
+			Code:         file.Code,
+			Name:         file.Name,
+			LinesPerPage: file.LinesPerPage,
+			TotalPages:   len(pages),
+		},
+		Pages:  pages,
+		Starts: file.Pages,
+	}, nil
+}
+
+// defaultMushafPages mengembalikan awal halaman DefaultMushafLayout untuk pembagian "page"
+func defaultMushafPages(layouts []seedMushaf) [][2]int {
+	for _, layout := range layouts {
+		if layout.Layout.Code == domain.DefaultMushafLayout {
+			return layout.Starts
+		}
+	}
+	return nil
+}
+
+// syncMushaf menyamakan tabel mushaf_* dengan data seed. Layout yang berbeda ditulis ulang
+// seluruhnya (baris lama ikut terhapus lewat ON DELETE CASCADE). Tanpa overwrite hanya layout yang
+// belum ada yang ditambah. Mengembalikan jumlah layout yang berubah.
+func syncMushaf(tx *gorm.DB, layouts []seedMushaf, overwrite bool) (int, error) {
+	current, err := loadMushafLayouts(tx)
+	if err != nil {
+		return 0, err
+	}
+
+	existing := make(map[string]seedMushaf, len(current))
+	for _, layout := range current {
+		existing[layout.Layout.Code] = layout
+	}
+
+	changed := 0
+	for _, layout := range layouts {
+		old, ok := existing[layout.Layout.Code]
+		delete(existing, layout.Layout.Code)
+		if ok && (!overwrite || mushafEqual(old, layout)) {
+			continue
+		}
+
+		if err := tx.Where("code = ?", layout.Layout.Code).Delete(&domain.MushafLayout{}).Error; err != nil {
+			return 0, fmt.Errorf("replace mushaf %s: %w", layout.Layout.Code, err)
+		}
+		if err := tx.Create(&layout.Layout).Error; err != nil {
+			return 0, fmt.Errorf("insert mushaf %s: %w", layout.Layout.Code, err)
+		}
+		if err := tx.Omit("Lines").CreateInBatches(layout.Pages, 200).Error; err != nil {
+			return 0, fmt.Errorf("insert mushaf %s pages: %w", layout.Layout.Code, err)
+		}
+
+		var lines []domain.MushafLine
+		for _, page := range layout.Pages {
+			lines = append(lines, page.Lines...)
+		}
+		if len(lines) > 0 {
+			if err := tx.CreateInBatches(lines, 500).Error; err != nil {
+				return 0, fmt.Errorf("insert mushaf %s lines: %w", layout.Layout.Code, err)
+			}
+		}
+		changed++
+	}
+
+	if overwrite {
+		for code := range existing {
+			if err := tx.Where("code = ?", code).Delete(&domain.MushafLayout{}).Error; err != nil {
+				return 0, fmt.Errorf("delete mushaf %s: %w", code, err)
+			}
+			changed++
+		}
+	}
+
+	return changed, nil
+}
+
+// loadMushafLayouts membaca seluruh layout beserta halaman dan barisnya dari database
+func loadMushafLayouts(tx *gorm.DB) ([]seedMushaf, error) {
+	var layouts []domain.MushafLayout
+	if err := tx.Order("code ASC").Find(&layouts).Error; err != nil {
+		return nil, err
+	}
+
+	result := make([]seedMushaf, 0, len(layouts))
+	for _, layout := range layouts {
+		var pages []domain.MushafPage
+		err := tx.Preload("Lines", func(db *gorm.DB) *gorm.DB {
+			return db.Order("line ASC")
+		}).Where("layout = ?", layout.Code).Order("page ASC").Find(&pages).Error
+		if err != nil {
+			return nil, err
+		}
+
+		starts := make([][2]int, len(pages))
+		for i, page := range pages {
+			starts[i] = [2]int{page.StartSurah, page.StartAyah}
+		}
+		result = append(result, seedMushaf{Layout: layout, Pages: pages, Starts: starts})
+	}
+	return result, nil
+}
+
+func mushafEqual(a, b seedMushaf) bool {
+	if a.Layout != b.Layout || len(a.Pages) != len(b.Pages) {
+		return false
+	}
+	for i := range a.Pages {
+		pa, pb := a.Pages[i], b.Pages[i]
+		if len(pa.Lines) == 0 && len(pb.Lines) == 0 {
+			pa.Lines, pb.Lines = nil, nil
+		}
+		if !reflect.DeepEqual(pa, pb) {
+			return false
+		}
+	}
+	return true
+}
+
+// encodeSeedMushaf menulis ulang mushaf/<code>.json dari isi database (dipakai dataset export)
+func encodeSeedMushaf(layout seedMushaf, idx *ayahIndex) ([]byte, error) {
+	var buf bytes.Buffer
+	buf.WriteString("{\r\n")
+
+	for _, field := range []struct {
+		key   string
+		value interface{}
+	}{
+		{"code", layout.Layout.Code},
+		{"name", layout.Layout.Name},
+		{"lines_per_page", layout.Layout.LinesPerPage},
+	} {
+		value, err := encodeSeedJSON(field.value, "")
+		if err != nil {
+			return nil, err
+		}
+		fmt.Fprintf(&buf, "  %q: %s,\r\n", field.key, value)
+	}
+
+	buf.WriteString("  \"pages\": [\r\n")
+	var rows []string
+	for i := 0; i < len(layout.Starts); i += 10 {
+		end := min(i+10, len(layout.Starts))
+		items := make([]string, 0, end-i)
+		for _, pos := range layout.Starts[i:end] {
+			items = append(items, fmt.Sprintf("[%d, %d]", pos[0], pos[1]))
+		}
+		rows = append(rows, "    "+strings.Join(items, ", "))
+	}
+	if len(rows) > 0 {
+		buf.WriteString(strings.Join(rows, ",\r\n") + "\r\n")
+	}
+	buf.WriteString("  ]")
+
+	var lines []string
+	for _, page := range layout.Pages {
+		for _, line := range page.Lines {
+			item := fmt.Sprintf("    { \"page\": %d, \"line\": %d, \"type\": %q, \"surah\": %d", line.Page, line.Line, line.Type, line.SurahID)
+			if line.FirstAyah != nil && line.LastAyah != nil {
+				_, start := idx.position(*line.FirstAyah)
+				_, end := idx.position(*line.LastAyah)
+				item += fmt.Sprintf(", \"start\": %d, \"end\": %d", start, end)
+			}
+			lines = append(lines, item+" }")
+		}
+	}
+	if len(lines) > 0 {
+		buf.WriteString(",\r\n  \"lines\": [\r\n" + strings.Join(lines, ",\r\n") + "\r\n  ]")
+	}
+
+	buf.WriteString("\r\n}")
+	return buf.Bytes(), nil
+}
@@ -0,0 +1,400 @@
+package database
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path"
+	"sort"
+	"strings"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	"khalif-alquran/internal/domain"
+
+)
+
+// Satu file per riwayat selain Hafs (riwayat/<code>.json), dipakai baik oleh seeding maupun `import riwayah`.
+// Setiap ayat ditulis dengan nomornya sendiri beserta rentang ayat Hafs yang dicakupnya:
+//
+//	{ "surah": 1, "ayah": 6, "hafs_from": 7, "text": "..." }
+//
+// hafs_to boleh dikosongkan jika sama dengan hafs_from. Folder ini opsional, tetapi file di dalamnya
+// harus mencakup seluruh surah; riwayat yang baru sebagian dimuat lewat `import riwayah`.
+const seedRiwayahPattern = "riwayat/*.json"
+
+// errRiwayahIncomplete: file seed riwayat tidak mencakup seluruh surah atau jumlah ayatnya
+// tidak sama dengan total_ayahs
+var errRiwayahIncomplete = errors.New("riwayah seed file is incomplete")
+
+type RiwayahFile struct {
+	domain.Riwayah
+	Ayahs []RiwayahFileAyah `json:"ayahs"`
+}
+
+type RiwayahFileAyah struct {
+	Surah    int    `json:"surah"`
+	Ayah     int    `json:"ayah"`
+	HafsFrom int    `json:"hafs_from"`
+	HafsTo   int    `json:"hafs_to,omitempty"`
+	Text     string `json:"text"`
+}
+
+type RiwayahImportReport struct {
+	Riwayah   string `json:"riwayah"`
+	Surahs    int    `json:"surahs"`
+	Ayahs     int    `json:"ayahs"`
+	Inserted  int    `json:"inserted"`
+	Updated   int    `json:"updated"`
+	Unchanged int    `json:"unchanged"`
+	Deleted   int    `json:"deleted"`
+}
+
+// isExtraRiwayah bernilai true untuk riwayat yang disimpan di riwayah_mappings/riwayah_ayahs
+func isExtraRiwayah(code string) bool {
+	return code == domain.RiwayahWarsh || code == domain.RiwayahQalun
+}
+
+// ParseRiwayahFile membaca satu file riwayat dari disk
+func ParseRiwayahFile(path string) (*RiwayahFile, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	return ParseRiwayah(f)
+}
+
+func ParseRiwayah(r io.Reader) (*RiwayahFile, error) {
+	var file RiwayahFile
+	if err := json.NewDecoder(r).Decode(&file); err != nil {
+		return nil, err
+	}
+
+	if !isExtraRiwayah(file.Code) {
+		return nil, fmt.Errorf("unknown riwayah %q, expected %s or %s (%s is stored in ayahs)", file.Code, domain.RiwayahWarsh, domain.RiwayahQalun, domain.DefaultRiwayah)
+	}
+	if file.Name == "" || file.TotalAyahs < 1 {
+		return nil, fmt.Errorf("riwayah needs name and total_ayahs")
+	}
+
+	for i, a := range file.Ayahs {
+		if a.HafsTo == 0 {
+			file.Ayahs[i].HafsTo = a.HafsFrom
+		}
+		file.Ayahs[i].Text = strings.TrimSpace(a.Text)
+	}
+
+	return &file, nil
+}
+
+// riwayahRows memvalidasi ayat-ayat riwayat terhadap jumlah ayat Hafs per surah. Setiap surah di file
+// harus lengkap (nomor 1..n tanpa lompatan) dan rentang Hafs-nya tidak boleh mundur; satu ayat Hafs boleh
+// dicakup dua ayat riwayat (dipecah), tapi dua rentang tidak boleh tumpang tindih lebih dari itu.
+// Jika file mencakup seluruh surah, jumlah ayatnya harus sama dengan total_ayahs.
+func riwayahRows(file *RiwayahFile, counts map[int]int) ([]domain.RiwayahMapping, []domain.RiwayahAyah, error) {
+	items := make([]RiwayahFileAyah, len(file.Ayahs))
+	copy(items, file.Ayahs)
+	sort.SliceStable(items, func(i, j int) bool {
+		if items[i].Surah != items[j].Surah {
+			return items[i].Surah < items[j].Surah
+		}
+		return items[i].Ayah < items[j].Ayah
+	})
+
+	mappings := make([]domain.RiwayahMapping, 0, len(items))
+	texts := make([]domain.RiwayahAyah, 0, len(items))
+	surahs := make(map[int]bool)
+	for i, item := range items {
+		count, ok := counts[item.Surah]
+		if !ok {
+			return nil, nil, fmt.Errorf("%d:%d: surah is not in the catalog", item.Surah, item.Ayah)
+		}
+
+		prev := RiwayahFileAyah{Surah: item.Surah}
+		if i > 0 && items[i-1].Surah == item.Surah {
+			prev = items[i-1]
+		}
+		if item.Ayah != prev.Ayah+1 {
+			return nil, nil, fmt.Errorf("%d:%d: ayah out of sequence, expected %d", item.Surah, item.Ayah, prev.Ayah+1)
+		}
+		if item.HafsFrom < 1 || item.HafsTo < item.HafsFrom || item.HafsTo > count {
+			return nil, nil, fmt.Errorf("%d:%d: invalid hafs range %d-%d, surah has %d ayahs in hafs", item.Surah, item.Ayah, item.HafsFrom, item.HafsTo, count)
+		}
+		if prev.Ayah > 0 && (item.HafsFrom < prev.HafsTo || (item.HafsFrom == prev.HafsTo && (prev.HafsFrom != prev.HafsTo || item.HafsFrom != item.HafsTo))) {
+			return nil, nil, fmt.Errorf("%d:%d: hafs range %d-%d overlaps the previous ayah (%d-%d)", item.Surah, item.Ayah, item.HafsFrom, item.HafsTo, prev.HafsFrom, prev.HafsTo)
+		}
+		if item.Text == "" {
+			return nil, nil, fmt.Errorf("%d:%d: empty text", item.Surah, item.Ayah)
+		}
+
+		surahs[item.Surah] = true
+		mappings = append(mappings, domain.RiwayahMapping{
+			Riwayah:  file.Code,
+			SurahID:  uint(item.Surah),
+			Number:   item.Ayah,
+			HafsFrom: item.HafsFrom,
+			HafsTo:   item.HafsTo,
+		})
+		texts = append(texts, domain.RiwayahAyah{
+			Riwayah:    file.Code,
+			SurahID:    uint(item.Surah),
+			Number:     item.Ayah,
+			TextArabic: item.Text,
+		})
+	}
+
+	if len(surahs) == totalSurahs && len(mappings) != file.TotalAyahs {
+		return nil, nil, fmt.Errorf("%w: file has %d ayahs but total_ayahs is %d", errRiwayahIncomplete, len(mappings), file.TotalAyahs)
+	}
+	return mappings, texts, nil
+}
+
+// loadSeedRiwayat membaca semua file di riwayat/. Folder ini opsional. Setiap file harus mencakup
+// seluruh surah (riwayahRows lalu memastikan jumlah ayatnya sama dengan total_ayahs), karena surah
+// yang tidak dipetakan tidak bisa disajikan dengan riwayat tersebut.
+func loadSeedRiwayat(source fs.FS, counts map[int]int) ([]*RiwayahFile, error) {
+	files, err := fs.Glob(source, seedRiwayahPattern)
+	if err != nil {
+		return nil, err
+	}
+
+	var riwayat []*RiwayahFile
+	for _, filename := range files {
+		f, err := source.Open(filename)
+		if err != nil {
+			return nil, fmt.Errorf("read %s: %w", filename, err)
+		}
+		file, err := ParseRiwayah(f)
+		f.Close()
+		if err != nil {
+			return nil, fmt.Errorf("parse %s: %w", filename, err)
+		}
+
+		if file.Code != strings.TrimSuffix(path.Base(filename), ".json") {
+			return nil, fmt.Errorf("%s: code %q must match the file name", filename, file.Code)
+		}
+		mappings, _, err := riwayahRows(file, counts)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", filename, err)
+		}
+
+		surahs := make(map[uint]bool)
+		for _, m := range mappings {
+			surahs[m.SurahID] = true
+		}
+		if len(surahs) != totalSurahs {
+			return nil, fmt.Errorf("%s: %w: covers %d of %d surahs (%d of %d ayahs)",
+				filename, errRiwayahIncomplete, len(surahs), totalSurahs, len(mappings), file.TotalAyahs)
+		}
+		riwayat = append(riwayat, file)
+	}
+	return riwayat, nil
+}
+
+// ImportRiwayah menyimpan satu riwayat dalam satu transaksi. Isi riwayat diganti seluruhnya:
+// ayat yang tidak ada di file dihapus, sehingga file selalu menjadi sumber kebenaran.
+func ImportRiwayah(db *gorm.DB, file *RiwayahFile) (*RiwayahImportReport, error) {
+	var report *RiwayahImportReport
+
+	err := db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Exec("SELECT pg_advisory_xact_lock(?)", seedLockKey).Error; err != nil {
+			return err
+		}
+
+		var surahs []domain.Surah
+		if err := tx.Select("number, total_ayahs").Find(&surahs).Error; err != nil {
+			return err
+		}
+		counts := make(map[int]int, len(surahs))
+		for _, s := range surahs {
+			counts[s.Number] = s.TotalAyahs
+		}
+
+		var err error
+		report, err = syncRiwayah(tx, file, counts, true)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return report, nil
+}
+
+// syncRiwayat menyamakan setiap riwayat yang punya file seed. Riwayat lain di database
+// (hasil `import riwayah`) dibiarkan. Mengembalikan jumlah ayat yang berubah.
+func syncRiwayat(tx *gorm.DB, riwayat []*RiwayahFile, counts map[int]int, overwrite bool) (int, error) {
+	changed := 0
+	for _, file := range riwayat {
+		report, err := syncRiwayah(tx, file, counts, overwrite)
+		if err != nil {
+			return 0, err
+		}
+		changed += report.Inserted + report.Updated + report.Deleted
+	}
+	return changed, nil
+}
+
+// syncRiwayah menyamakan satu riwayat dengan file. Tanpa overwrite hanya ayat yang belum ada
+// yang ditambah; katalog riwayat dan ayat yang sudah ada tidak diubah atau dihapus.
+func syncRiwayah(tx *gorm.DB, file *RiwayahFile, counts map[int]int, overwrite bool) (*RiwayahImportReport, error) {
+	mappings, texts, err := riwayahRows(file, counts)
+	if err != nil {
+		return nil, err
+	}
+
+	report := &RiwayahImportReport{Riwayah: file.Code, Ayahs: len(mappings)}
+	surahs := make(map[uint]bool)
+	for _, m := range mappings {
+		surahs[m.SurahID] = true
+	}
+	report.Surahs = len(surahs)
+
+	var riwayah domain.Riwayah
+	if err := tx.Where("code = ?", file.Code).Limit(1).Find(&riwayah).Error; err != nil {
+		return nil, err
+	}
+	if riwayah != file.Riwayah && (overwrite || riwayah.Code == "") {
+		if err := tx.Clauses(clause.OnConflict{UpdateAll: true}).Create(&file.Riwayah).Error; err != nil {
+			return nil, fmt.Errorf("upsert riwayah %s: %w", file.Code, err)
+		}
+	}
+
+	var currentMappings []domain.RiwayahMapping
+	if err := tx.Where("riwayah = ?", file.Code).Find(&currentMappings).Error; err != nil {
+		return nil, err
+	}
+	existing := make(map[[2]int]domain.RiwayahMapping, len(currentMappings))
+	for _, m := range currentMappings {
+		existing[[2]int{int(m.SurahID), m.Number}] = m
+	}
+
+	var currentTexts []domain.RiwayahAyah
+	if err := tx.Where("riwayah = ?", file.Code).Find(&currentTexts).Error; err != nil {
+		return nil, err
+	}
+	existingTexts := make(map[[2]int]string, len(currentTexts))
+	for _, t := range currentTexts {
+		existingTexts[[2]int{int(t.SurahID), t.Number}] = t.TextArabic
+	}
+
+	var mappingUpserts []domain.RiwayahMapping
+	var textUpserts []domain.RiwayahAyah
+	for i, m := range mappings {
+		key := [2]int{int(m.SurahID), m.Number}
+		old, ok := existing[key]
+		delete(existing, key)
+		text, hasText := existingTexts[key]
+		switch {
+		case !ok:
+			report.Inserted++
+		case !overwrite || old == m && hasText && text == texts[i].TextArabic:
+			report.Unchanged++
+			continue
+		default:
+			report.Updated++
+		}
+		mappingUpserts = append(mappingUpserts, m)
+		textUpserts = append(textUpserts, texts[i])
+	}
+
+	// Teks ikut terhapus lewat ON DELETE CASCADE
+	if overwrite {
+		for key := range existing {
+			err := tx.Where("riwayah = ? AND surah_id = ? AND number = ?", file.Code, key[0], key[1]).
+				Delete(&domain.RiwayahMapping{}).Error
+			if err != nil {
+				return nil, fmt.Errorf("delete riwayah %s %d:%d: %w", file.Code, key[0], key[1], err)
+			}
+			report.Deleted++
+		}
+	}
+
+	if len(mappingUpserts) > 0 {
+		if err := tx.Clauses(clause.OnConflict{UpdateAll: true}).CreateInBatches(mappingUpserts, 500).Error; err != nil {
+			return nil, fmt.Errorf("upsert riwayah mappings %s: %w", file.Code, err)
+		}
+		if err := tx.Clauses(clause.OnConflict{UpdateAll: true}).CreateInBatches(textUpserts, 500).Error; err != nil {
+			return nil, fmt.Errorf("upsert riwayah texts %s: %w", file.Code, err)
+		}
+	}
+
+	return report, nil
+}
+
+// encodeSeedRiwayah menulis ulang riwayat/<code>.json dari isi database, satu ayat per baris agar mudah di-diff
+func encodeSeedRiwayah(riwayah domain.Riwayah, mappings []domain.RiwayahMapping, texts []domain.RiwayahAyah) ([]byte, error) {
+	sort.Slice(mappings, func(i, j int) bool {
+		if mappings[i].SurahID != mappings[j].SurahID {
+			return mappings[i].SurahID < mappings[j].SurahID
+		}
+		return mappings[i].Number < mappings[j].Number
+	})
+
+	byAyah := make(map[[2]int]string, len(texts))
+	for _, t := range texts {
+		byAyah[[2]int{int(t.SurahID), t.Number}] = t.TextArabic
+	}
+
+	type field struct {
+		key   string
+		value interface{}
+	}
+
+	var buf bytes.Buffer
+	buf.WriteString("{\r\n")
+
+	header := []field{
+		{"code", riwayah.Code},
+		{"name", riwayah.Name},
+		{"qiraah", riwayah.Qiraah},
+		{"numbering", riwayah.Numbering},
+		{"total_ayahs", riwayah.TotalAyahs},
+		{"source", riwayah.Source},
+		{"license", riwayah.License},
+	}
+	for _, f := range header {
+		value, err := encodeSeedJSON(f.value, "")
+		if err != nil {
+			return nil, err
+		}
+		fmt.Fprintf(&buf, "  %q: %s,\r\n", f.key, value)
+	}
+	buf.WriteString("  \"ayahs\": [\r\n")
+
+	for i, m := range mappings {
+		fields := []field{
+			{"surah", m.SurahID},
+			{"ayah", m.Number},
+			{"hafs_from", m.HafsFrom},
+		}
+		if m.HafsTo != m.HafsFrom {
+			fields = append(fields, field{"hafs_to", m.HafsTo})
+		}
+		fields = append(fields, field{"text", byAyah[[2]int{int(m.SurahID), m.Number}]})
+
+		parts := make([]string, 0, len(fields))
+		for _, f := range fields {
+			value, err := encodeSeedJSON(f.value, "")
+			if err != nil {
+				return nil, err
+			}
+			parts = append(parts, fmt.Sprintf("%q: %s", f.key, value))
+		}
+
+		buf.WriteString("    { " + strings.Join(parts, ", ") + " }")
+		if i < len(mappings)-1 {
+			buf.WriteString(",")
+		}
+		buf.WriteString("\r\n")
+	}
+
+	buf.WriteString("  ]\r\n}")
+	return buf.Bytes(), nil
+}
@@ -0,0 +1,16 @@
+-- Rollback skema awal. Role readonly_user tidak dihapus karena berlaku
+-- untuk seluruh cluster Postgres, bukan hanya database ini.
+-- Trigger bookmarks ikut terhapus bersama tabelnya.
+DROP TABLE IF EXISTS bookmarks;
+
+--SEPARATOR--
+
+DROP TABLE IF EXISTS ayahs;
+
+--SEPARATOR--
+
+DROP TABLE IF EXISTS surahs;
+
+--SEPARATOR--
+
+DROP FUNCTION IF EXISTS update_updated_at_column();
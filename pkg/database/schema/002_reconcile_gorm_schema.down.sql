@@ -0,0 +1,23 @@
+-- Kembali ke bentuk 001_init_schema. Bentuk lama buatan GORM (surahs.id) tidak dipulihkan.
+-- Konten tafsir, asbabun nuzul dan tajwid ikut terhapus bersama kolomnya.
+-- number_in_quran dibiarkan nullable karena baris lama mungkin belum terisi.
+DROP TRIGGER IF EXISTS update_ayahs_modtime ON ayahs;
+
+--SEPARATOR--
+
+DROP TRIGGER IF EXISTS update_surahs_modtime ON surahs;
+
+--SEPARATOR--
+
+ALTER TABLE ayahs
+    DROP COLUMN IF EXISTS tafsir,
+    DROP COLUMN IF EXISTS asbabun_nuzul,
+    DROP COLUMN IF EXISTS tajwid_info,
+    DROP COLUMN IF EXISTS created_at,
+    DROP COLUMN IF EXISTS updated_at;
+
+--SEPARATOR--
+
+ALTER TABLE surahs
+    DROP COLUMN IF EXISTS created_at,
+    DROP COLUMN IF EXISTS updated_at;
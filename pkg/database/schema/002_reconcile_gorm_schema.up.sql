@@ -0,0 +1,173 @@
+-- Menyatukan dua bentuk skema yang pernah ada di production:
+-- (a) tabel buatan GORM AutoMigrate (surahs.id sebagai primary key, kolom TEXT/BIGINT)
+-- (b) tabel buatan 001_init_schema (surahs.number sebagai primary key)
+-- Setelah migrasi ini, file SQL di folder schema adalah satu-satunya sumber kebenaran.
+-- Semua query memakai current_schema() (bukan 'public') agar bisa dijalankan di skema lain oleh drift checker.
+
+-- Bentuk (a): ayahs/bookmarks menyimpan surahs.id (urutan file seed), bukan nomor surah
+DO $$
+DECLARE
+    con RECORD;
+BEGIN
+    IF EXISTS (
+        SELECT 1 FROM information_schema.columns
+        WHERE table_schema = current_schema() AND table_name = 'surahs' AND column_name = 'id'
+    ) THEN
+        FOR con IN
+            SELECT conname, conrelid::regclass AS tbl
+            FROM pg_constraint
+            WHERE contype = 'f' AND confrelid = 'surahs'::regclass
+        LOOP
+            EXECUTE format('ALTER TABLE %s DROP CONSTRAINT %I', con.tbl, con.conname);
+        END LOOP;
+
+        UPDATE ayahs a SET surah_id = s.number FROM surahs s WHERE a.surah_id = s.id;
+        UPDATE bookmarks b SET surah_id = s.number FROM surahs s WHERE b.surah_id = s.id;
+
+        ALTER TABLE surahs DROP CONSTRAINT IF EXISTS surahs_pkey;
+        ALTER TABLE surahs DROP COLUMN id;
+        ALTER TABLE surahs ADD PRIMARY KEY (number);
+    END IF;
+END
+$$;
+
+--SEPARATOR--
+
+DROP INDEX IF EXISTS idx_surahs_number;
+
+--SEPARATOR--
+
+DROP INDEX IF EXISTS idx_ayahs_surah_id;
+
+--SEPARATOR--
+
+DROP INDEX IF EXISTS idx_bookmarks_user_id;
+
+--SEPARATOR--
+
+DROP INDEX IF EXISTS idx_bookmarks_surah_id;
+
+--SEPARATOR--
+
+-- Tabel Surahs: samakan tipe kolom dan tambahkan timestamp yang dipakai domain.Surah
+ALTER TABLE surahs
+    ALTER COLUMN number TYPE INT,
+    ALTER COLUMN name TYPE VARCHAR(100),
+    ALTER COLUMN name SET NOT NULL,
+    ALTER COLUMN latin_name TYPE VARCHAR(100),
+    ALTER COLUMN latin_name SET NOT NULL,
+    ALTER COLUMN english_name TYPE VARCHAR(100),
+    ALTER COLUMN english_name SET NOT NULL,
+    ALTER COLUMN indonesian_name TYPE VARCHAR(100),
+    ALTER COLUMN indonesian_name SET NOT NULL,
+    ALTER COLUMN revelation_type TYPE VARCHAR(20),
+    ALTER COLUMN revelation_type SET NOT NULL,
+    ALTER COLUMN total_ayahs TYPE INT,
+    ALTER COLUMN total_ayahs SET NOT NULL,
+    ADD COLUMN IF NOT EXISTS created_at TIMESTAMPTZ DEFAULT NOW(),
+    ADD COLUMN IF NOT EXISTS updated_at TIMESTAMPTZ DEFAULT NOW();
+
+--SEPARATOR--
+
+-- Kolom timestamp buatan GORM tidak punya default
+ALTER TABLE surahs
+    ALTER COLUMN created_at SET DEFAULT NOW(),
+    ALTER COLUMN updated_at SET DEFAULT NOW();
+
+--SEPARATOR--
+
+-- Tabel Ayahs: kolom konten (tafsir, asbabun nuzul, tajwid) sebelumnya hanya ada di struct
+-- number_in_quran belum diisi oleh seeder, jadi sementara boleh NULL
+ALTER TABLE ayahs
+    ALTER COLUMN id TYPE INT,
+    ALTER COLUMN surah_id TYPE INT,
+    ALTER COLUMN surah_id SET NOT NULL,
+    ALTER COLUMN number TYPE INT,
+    ALTER COLUMN number SET NOT NULL,
+    ALTER COLUMN text_arabic SET NOT NULL,
+    ALTER COLUMN text_latin SET NOT NULL,
+    ALTER COLUMN translation SET NOT NULL,
+    ADD COLUMN IF NOT EXISTS number_in_quran INT,
+    ADD COLUMN IF NOT EXISTS audio_url TEXT,
+    ADD COLUMN IF NOT EXISTS tafsir TEXT,
+    ADD COLUMN IF NOT EXISTS asbabun_nuzul TEXT,
+    ADD COLUMN IF NOT EXISTS tajwid_info JSONB,
+    ADD COLUMN IF NOT EXISTS created_at TIMESTAMPTZ DEFAULT NOW(),
+    ADD COLUMN IF NOT EXISTS updated_at TIMESTAMPTZ DEFAULT NOW();
+
+--SEPARATOR--
+
+ALTER TABLE ayahs
+    ALTER COLUMN number_in_quran DROP NOT NULL,
+    ALTER COLUMN created_at SET DEFAULT NOW(),
+    ALTER COLUMN updated_at SET DEFAULT NOW();
+
+--SEPARATOR--
+
+-- Tabel Bookmarks: hapus duplikat (simpan yang terbaru) sebelum memasang unique constraint
+DELETE FROM bookmarks a
+USING bookmarks b
+WHERE a.id < b.id
+  AND a.user_id = b.user_id
+  AND a.surah_id = b.surah_id
+  AND a.ayah_number = b.ayah_number;
+
+--SEPARATOR--
+
+ALTER TABLE bookmarks
+    ALTER COLUMN id TYPE INT,
+    ALTER COLUMN user_id TYPE VARCHAR(100),
+    ALTER COLUMN user_id SET NOT NULL,
+    ALTER COLUMN surah_id TYPE INT,
+    ALTER COLUMN surah_id SET NOT NULL,
+    ALTER COLUMN ayah_number TYPE INT,
+    ALTER COLUMN ayah_number SET NOT NULL,
+    ALTER COLUMN created_at SET DEFAULT NOW(),
+    ADD COLUMN IF NOT EXISTS updated_at TIMESTAMPTZ DEFAULT NOW();
+
+--SEPARATOR--
+
+-- Pasang ulang constraint dari 001 yang tidak dibuat oleh GORM
+DO $$
+BEGIN
+    IF NOT EXISTS (SELECT 1 FROM pg_constraint WHERE conrelid = 'ayahs'::regclass AND conname = 'ayahs_surah_id_number_key') THEN
+        ALTER TABLE ayahs ADD CONSTRAINT ayahs_surah_id_number_key UNIQUE (surah_id, number);
+    END IF;
+
+    IF NOT EXISTS (SELECT 1 FROM pg_constraint WHERE conrelid = 'ayahs'::regclass AND conname = 'fk_surah') THEN
+        ALTER TABLE ayahs ADD CONSTRAINT fk_surah
+            FOREIGN KEY (surah_id) REFERENCES surahs(number) ON DELETE CASCADE;
+    END IF;
+
+    IF NOT EXISTS (SELECT 1 FROM pg_constraint WHERE conrelid = 'bookmarks'::regclass AND conname = 'bookmarks_user_id_surah_id_ayah_number_key') THEN
+        ALTER TABLE bookmarks ADD CONSTRAINT bookmarks_user_id_surah_id_ayah_number_key
+            UNIQUE (user_id, surah_id, ayah_number);
+    END IF;
+
+    IF NOT EXISTS (SELECT 1 FROM pg_constraint WHERE conrelid = 'bookmarks'::regclass AND conname = 'fk_bookmark_surah') THEN
+        ALTER TABLE bookmarks ADD CONSTRAINT fk_bookmark_surah
+            FOREIGN KEY (surah_id) REFERENCES surahs(number) ON DELETE CASCADE;
+    END IF;
+END
+$$;
+
+--SEPARATOR--
+
+-- Trigger updated_at untuk data statis, karena seeder menulis lewat SQL mentah
+DROP TRIGGER IF EXISTS update_surahs_modtime ON surahs;
+
+--SEPARATOR--
+
+CREATE TRIGGER update_surahs_modtime
+BEFORE UPDATE ON surahs
+FOR EACH ROW EXECUTE PROCEDURE update_updated_at_column();
+
+--SEPARATOR--
+
+DROP TRIGGER IF EXISTS update_ayahs_modtime ON ayahs;
+
+--SEPARATOR--
+
+CREATE TRIGGER update_ayahs_modtime
+BEFORE UPDATE ON ayahs
+FOR EACH ROW EXECUTE PROCEDURE update_updated_at_column();
@@ -0,0 +1,6 @@
+DROP INDEX IF EXISTS idx_ayahs_number_in_quran;
+
+--SEPARATOR--
+
+ALTER TABLE ayahs
+    ALTER COLUMN number_in_quran DROP NOT NULL;
@@ -0,0 +1,20 @@
+-- Isi number_in_quran untuk baris lama: jumlah ayat seluruh surah sebelumnya + nomor ayat.
+-- Seeder akan menghitung ulang jika katalog surah di database belum lengkap.
+UPDATE ayahs a
+SET number_in_quran = o.ayah_offset + a.number
+FROM (
+    SELECT number, SUM(total_ayahs) OVER (ORDER BY number) - total_ayahs AS ayah_offset
+    FROM surahs
+) o
+WHERE o.number = a.surah_id
+  AND a.number_in_quran IS NULL;
+
+--SEPARATOR--
+
+ALTER TABLE ayahs
+    ALTER COLUMN number_in_quran SET NOT NULL;
+
+--SEPARATOR--
+
+-- Lookup "lompat ke ayat ke-N" (GET /quran/ayahs/{global_number})
+CREATE INDEX IF NOT EXISTS idx_ayahs_number_in_quran ON ayahs (number_in_quran);
@@ -0,0 +1,12 @@
+ALTER TABLE ayahs
+    DROP COLUMN IF EXISTS juz,
+    DROP COLUMN IF EXISTS hizb,
+    DROP COLUMN IF EXISTS page;
+
+--SEPARATOR--
+
+DROP TABLE IF EXISTS sajdahs;
+
+--SEPARATOR--
+
+DROP TABLE IF EXISTS divisions;
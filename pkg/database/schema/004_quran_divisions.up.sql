@@ -0,0 +1,37 @@
+-- Pembagian mushaf (juz, hizb, rub' al-hizb, manzil, ruku', halaman) sebagai rentang ayat.
+-- first_ayah/last_ayah adalah number_in_quran agar pencarian rentang cukup satu BETWEEN.
+CREATE TABLE IF NOT EXISTS divisions (
+    type VARCHAR(10) NOT NULL, -- juz | hizb | rub | manzil | ruku | page
+    number INT NOT NULL,
+    start_surah INT NOT NULL,
+    start_ayah INT NOT NULL,
+    end_surah INT NOT NULL,
+    end_ayah INT NOT NULL,
+    first_ayah INT NOT NULL,
+    last_ayah INT NOT NULL,
+    PRIMARY KEY (type, number)
+);
+
+--SEPARATOR--
+
+CREATE INDEX IF NOT EXISTS idx_divisions_range ON divisions (type, first_ayah, last_ayah);
+
+--SEPARATOR--
+
+-- Posisi ayat sajdah tilawah
+CREATE TABLE IF NOT EXISTS sajdahs (
+    number INT PRIMARY KEY,
+    surah_id INT NOT NULL,
+    ayah_number INT NOT NULL,
+    type VARCHAR(20) NOT NULL, -- recommended | obligatory
+    CONSTRAINT fk_sajdah_surah FOREIGN KEY (surah_id) REFERENCES surahs(number) ON DELETE CASCADE,
+    UNIQUE (surah_id, ayah_number)
+);
+
+--SEPARATOR--
+
+-- Anotasi per ayat, diisi seeder dari tabel divisions
+ALTER TABLE ayahs
+    ADD COLUMN IF NOT EXISTS juz INT,
+    ADD COLUMN IF NOT EXISTS hizb INT,
+    ADD COLUMN IF NOT EXISTS page INT;
@@ -0,0 +1,9 @@
+DROP TABLE IF EXISTS mushaf_lines;
+
+--SEPARATOR--
+
+DROP TABLE IF EXISTS mushaf_pages;
+
+--SEPARATOR--
+
+DROP TABLE IF EXISTS mushaf_layouts;
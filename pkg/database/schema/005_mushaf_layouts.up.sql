@@ -0,0 +1,42 @@
+-- Layout cetakan mushaf (Madinah 15 baris, Indo-Pak 16 baris, dst)
+CREATE TABLE IF NOT EXISTS mushaf_layouts (
+    code VARCHAR(20) PRIMARY KEY,
+    name VARCHAR(100) NOT NULL,
+    lines_per_page INT NOT NULL,
+    total_pages INT NOT NULL
+);
+
+--SEPARATOR--
+
+-- Rentang ayat per halaman; first_ayah/last_ayah adalah number_in_quran
+CREATE TABLE IF NOT EXISTS mushaf_pages (
+    layout VARCHAR(20) NOT NULL,
+    page INT NOT NULL,
+    start_surah INT NOT NULL,
+    start_ayah INT NOT NULL,
+    end_surah INT NOT NULL,
+    end_ayah INT NOT NULL,
+    first_ayah INT NOT NULL,
+    last_ayah INT NOT NULL,
+    PRIMARY KEY (layout, page),
+    CONSTRAINT fk_mushaf_page_layout FOREIGN KEY (layout) REFERENCES mushaf_layouts(code) ON DELETE CASCADE
+);
+
+--SEPARATOR--
+
+CREATE INDEX IF NOT EXISTS idx_mushaf_pages_range ON mushaf_pages (layout, first_ayah, last_ayah);
+
+--SEPARATOR--
+
+-- Baris per halaman (opsional, hanya jika data baris tersedia untuk layout tersebut)
+CREATE TABLE IF NOT EXISTS mushaf_lines (
+    layout VARCHAR(20) NOT NULL,
+    page INT NOT NULL,
+    line INT NOT NULL,
+    type VARCHAR(20) NOT NULL, -- surah_name | basmallah | ayah
+    surah_id INT NOT NULL,
+    first_ayah INT,
+    last_ayah INT,
+    PRIMARY KEY (layout, page, line),
+    CONSTRAINT fk_mushaf_line_page FOREIGN KEY (layout, page) REFERENCES mushaf_pages(layout, page) ON DELETE CASCADE
+);
@@ -0,0 +1,5 @@
+ALTER TABLE sajdahs
+    DROP COLUMN IF EXISTS hanafi,
+    DROP COLUMN IF EXISTS maliki,
+    DROP COLUMN IF EXISTS shafii,
+    DROP COLUMN IF EXISTS hanbali;
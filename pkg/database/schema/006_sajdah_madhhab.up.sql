@@ -0,0 +1,6 @@
+-- Hukum sajdah tilawah menurut empat mazhab: obligatory | recommended | none
+ALTER TABLE sajdahs
+    ADD COLUMN IF NOT EXISTS hanafi VARCHAR(20) NOT NULL DEFAULT '',
+    ADD COLUMN IF NOT EXISTS maliki VARCHAR(20) NOT NULL DEFAULT '',
+    ADD COLUMN IF NOT EXISTS shafii VARCHAR(20) NOT NULL DEFAULT '',
+    ADD COLUMN IF NOT EXISTS hanbali VARCHAR(20) NOT NULL DEFAULT '';
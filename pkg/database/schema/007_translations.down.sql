@@ -0,0 +1,5 @@
+DROP TABLE IF EXISTS ayah_translations;
+
+--SEPARATOR--
+
+DROP TABLE IF EXISTS translations;
@@ -0,0 +1,21 @@
+-- Katalog edisi terjemahan. Teks edisi default (id.kemenag) tetap di kolom ayahs.translation.
+CREATE TABLE IF NOT EXISTS translations (
+    code VARCHAR(50) PRIMARY KEY, -- misal: en.sahih
+    language VARCHAR(10) NOT NULL,
+    name VARCHAR(200) NOT NULL,
+    translator VARCHAR(200) NOT NULL DEFAULT '',
+    source TEXT NOT NULL DEFAULT '',
+    license TEXT NOT NULL DEFAULT ''
+);
+
+--SEPARATOR--
+
+CREATE TABLE IF NOT EXISTS ayah_translations (
+    edition VARCHAR(50) NOT NULL,
+    surah_id INT NOT NULL,
+    ayah_number INT NOT NULL,
+    text TEXT NOT NULL,
+    PRIMARY KEY (edition, surah_id, ayah_number),
+    CONSTRAINT fk_ayah_translation_edition FOREIGN KEY (edition) REFERENCES translations(code) ON DELETE CASCADE,
+    CONSTRAINT fk_ayah_translation_ayah FOREIGN KEY (surah_id, ayah_number) REFERENCES ayahs(surah_id, number) ON DELETE CASCADE
+);
@@ -0,0 +1,5 @@
+DROP TABLE IF EXISTS tafsir_entries;
+
+--SEPARATOR--
+
+DROP TABLE IF EXISTS tafsir_editions;
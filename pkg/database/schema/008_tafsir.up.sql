@@ -0,0 +1,25 @@
+-- Edisi tafsir (Ibnu Katsir, Jalalayn, al-Misbah, Kemenag ringkas, dst)
+CREATE TABLE IF NOT EXISTS tafsir_editions (
+    code VARCHAR(50) PRIMARY KEY,
+    language VARCHAR(10) NOT NULL,
+    name VARCHAR(200) NOT NULL,
+    author VARCHAR(200) NOT NULL DEFAULT '',
+    form VARCHAR(10) NOT NULL, -- short | long
+    source TEXT NOT NULL DEFAULT '',
+    license TEXT NOT NULL DEFAULT ''
+);
+
+--SEPARATOR--
+
+-- Satu entri menjelaskan rentang ayat from_ayah..to_ayah dalam satu surah
+CREATE TABLE IF NOT EXISTS tafsir_entries (
+    edition VARCHAR(50) NOT NULL,
+    surah_id INT NOT NULL,
+    from_ayah INT NOT NULL,
+    to_ayah INT NOT NULL,
+    text TEXT NOT NULL,
+    PRIMARY KEY (edition, surah_id, from_ayah),
+    CONSTRAINT fk_tafsir_entry_edition FOREIGN KEY (edition) REFERENCES tafsir_editions(code) ON DELETE CASCADE,
+    CONSTRAINT fk_tafsir_entry_surah FOREIGN KEY (surah_id) REFERENCES surahs(number) ON DELETE CASCADE,
+    CONSTRAINT chk_tafsir_entry_range CHECK (from_ayah >= 1 AND from_ayah <= to_ayah)
+);
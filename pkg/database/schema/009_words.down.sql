@@ -0,0 +1 @@
+DROP TABLE IF EXISTS words;
\ No newline at end of file
@@ -0,0 +1,13 @@
+-- Kata per kata untuk mode belajar. Teks Uthmani per kata beserta transliterasi dan arti (id/en).
+CREATE TABLE IF NOT EXISTS words (
+    surah_id INT NOT NULL,
+    ayah_number INT NOT NULL,
+    position INT NOT NULL, -- urutan kata dalam ayat, mulai dari 1
+    text_uthmani TEXT NOT NULL,
+    transliteration TEXT NOT NULL DEFAULT '',
+    translation_id TEXT NOT NULL DEFAULT '',
+    translation_en TEXT NOT NULL DEFAULT '',
+    PRIMARY KEY (surah_id, ayah_number, position),
+    CONSTRAINT fk_word_ayah FOREIGN KEY (surah_id, ayah_number) REFERENCES ayahs(surah_id, number) ON DELETE CASCADE,
+    CONSTRAINT chk_word_position CHECK (position >= 1)
+);
\ No newline at end of file
@@ -0,0 +1 @@
+DROP TABLE IF EXISTS morphology_segments;
\ No newline at end of file
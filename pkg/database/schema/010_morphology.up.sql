@@ -0,0 +1,21 @@
+-- Morfologi per segmen kata dari Quranic Arabic Corpus (https://corpus.quran.com).
+-- Akar kata dan lemma disimpan dalam huruf Arab; kolom features menyimpan fitur asli (Buckwalter).
+CREATE TABLE IF NOT EXISTS morphology_segments (
+    surah_id INT NOT NULL,
+    ayah_number INT NOT NULL,
+    position INT NOT NULL,
+    segment INT NOT NULL,
+    form TEXT NOT NULL,
+    tag VARCHAR(20) NOT NULL,
+    kind VARCHAR(10) NOT NULL, -- prefix | stem | suffix
+    root VARCHAR(20) NOT NULL DEFAULT '',
+    lemma VARCHAR(100) NOT NULL DEFAULT '',
+    features TEXT NOT NULL DEFAULT '',
+    PRIMARY KEY (surah_id, ayah_number, position, segment),
+    CONSTRAINT fk_morphology_ayah FOREIGN KEY (surah_id, ayah_number) REFERENCES ayahs(surah_id, number) ON DELETE CASCADE
+);
+
+--SEPARATOR--
+
+-- Untuk daftar kemunculan dan frekuensi akar kata
+CREATE INDEX IF NOT EXISTS idx_morphology_segments_root ON morphology_segments (root) WHERE root <> '';
\ No newline at end of file
@@ -0,0 +1 @@
+DROP TABLE IF EXISTS ayah_scripts;
\ No newline at end of file
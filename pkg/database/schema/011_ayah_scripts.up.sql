@@ -0,0 +1,9 @@
+-- Teks Arab dalam edisi rasm lain (imlaei, indopak). Rasm Uthmani tetap di kolom ayahs.text_arabic.
+CREATE TABLE IF NOT EXISTS ayah_scripts (
+    script VARCHAR(20) NOT NULL,
+    surah_id INT NOT NULL,
+    ayah_number INT NOT NULL,
+    text TEXT NOT NULL,
+    PRIMARY KEY (script, surah_id, ayah_number),
+    CONSTRAINT fk_ayah_script_ayah FOREIGN KEY (surah_id, ayah_number) REFERENCES ayahs(surah_id, number) ON DELETE CASCADE
+);
\ No newline at end of file
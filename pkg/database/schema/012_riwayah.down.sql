@@ -0,0 +1,9 @@
+DROP TABLE IF EXISTS riwayah_ayahs;
+
+--SEPARATOR--
+
+DROP TABLE IF EXISTS riwayah_mappings;
+
+--SEPARATOR--
+
+DROP TABLE IF EXISTS riwayahs;
\ No newline at end of file
@@ -0,0 +1,38 @@
+-- Riwayat qira'at selain Hafs (Warsh, Qalun). Hafs tetap di tabel ayahs dengan penomoran Kufi.
+CREATE TABLE IF NOT EXISTS riwayahs (
+    code VARCHAR(20) PRIMARY KEY,
+    name VARCHAR(200) NOT NULL,
+    qiraah VARCHAR(100) NOT NULL DEFAULT '',
+    numbering VARCHAR(20) NOT NULL DEFAULT '', -- sistem penghitungan ayat, misal madani-2
+    total_ayahs INT NOT NULL,
+    source TEXT NOT NULL DEFAULT '',
+    license TEXT NOT NULL DEFAULT ''
+);
+
+--SEPARATOR--
+
+-- Pemetaan nomor ayat riwayat ke rentang ayat Hafs dalam surah yang sama.
+-- Jumlah ayat per surah suatu riwayat adalah jumlah barisnya di tabel ini.
+CREATE TABLE IF NOT EXISTS riwayah_mappings (
+    riwayah VARCHAR(20) NOT NULL,
+    surah_id INT NOT NULL,
+    number INT NOT NULL,
+    hafs_from INT NOT NULL,
+    hafs_to INT NOT NULL,
+    PRIMARY KEY (riwayah, surah_id, number),
+    CONSTRAINT fk_riwayah_mapping_riwayah FOREIGN KEY (riwayah) REFERENCES riwayahs(code) ON DELETE CASCADE,
+    CONSTRAINT fk_riwayah_mapping_surah FOREIGN KEY (surah_id) REFERENCES surahs(number) ON DELETE CASCADE,
+    CONSTRAINT chk_riwayah_mapping_range CHECK (number >= 1 AND hafs_from >= 1 AND hafs_from <= hafs_to)
+);
+
+--SEPARATOR--
+
+-- Teks Arab per ayat dalam penomoran riwayatnya sendiri
+CREATE TABLE IF NOT EXISTS riwayah_ayahs (
+    riwayah VARCHAR(20) NOT NULL,
+    surah_id INT NOT NULL,
+    number INT NOT NULL,
+    text_arabic TEXT NOT NULL,
+    PRIMARY KEY (riwayah, surah_id, number),
+    CONSTRAINT fk_riwayah_ayah_mapping FOREIGN KEY (riwayah, surah_id, number) REFERENCES riwayah_mappings(riwayah, surah_id, number) ON DELETE CASCADE
+);
\ No newline at end of file
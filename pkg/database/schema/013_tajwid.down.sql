@@ -0,0 +1,5 @@
+DROP TABLE IF EXISTS tajwid_annotations;
+
+--SEPARATOR--
+
+DROP TABLE IF EXISTS tajwid_rules;
@@ -0,0 +1,25 @@
+-- Katalog hukum tajwid beserta nama tampilan dan warna yang disarankan
+CREATE TABLE IF NOT EXISTS tajwid_rules (
+    code VARCHAR(50) PRIMARY KEY,
+    name_id VARCHAR(100) NOT NULL,
+    name_en VARCHAR(100) NOT NULL,
+    category VARCHAR(20) NOT NULL DEFAULT '', -- nun_sakinah | mim_sakinah | ghunnah | qalqalah | mad | lam | waqf
+    color VARCHAR(7) NOT NULL DEFAULT ''       -- hex, misal #169777
+);
+
+--SEPARATOR--
+
+-- Anotasi tajwid sebagai rentang karakter [start_offset, end_offset) pada teks Arab satu edisi rasm.
+-- Offset dihitung dalam karakter Unicode, bukan byte. Kolom tidak dinamai start/end karena END adalah kata kunci SQL.
+CREATE TABLE IF NOT EXISTS tajwid_annotations (
+    script VARCHAR(20) NOT NULL,
+    surah_id INT NOT NULL,
+    ayah_number INT NOT NULL,
+    start_offset INT NOT NULL,
+    rule VARCHAR(50) NOT NULL,
+    end_offset INT NOT NULL,
+    PRIMARY KEY (script, surah_id, ayah_number, start_offset, rule),
+    CONSTRAINT fk_tajwid_annotation_ayah FOREIGN KEY (surah_id, ayah_number) REFERENCES ayahs(surah_id, number) ON DELETE CASCADE,
+    CONSTRAINT fk_tajwid_annotation_rule FOREIGN KEY (rule) REFERENCES tajwid_rules(code) ON DELETE CASCADE,
+    CONSTRAINT chk_tajwid_annotation_range CHECK (start_offset >= 0 AND end_offset > start_offset)
+);
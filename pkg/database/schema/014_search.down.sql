@@ -0,0 +1,37 @@
+DROP INDEX IF EXISTS idx_tafsir_entries_search;
+
+--SEPARATOR--
+
+ALTER TABLE tafsir_entries DROP COLUMN IF EXISTS search_vector;
+
+--SEPARATOR--
+
+DROP INDEX IF EXISTS idx_ayah_translations_search;
+
+--SEPARATOR--
+
+ALTER TABLE ayah_translations DROP COLUMN IF EXISTS search_vector;
+
+--SEPARATOR--
+
+DROP INDEX IF EXISTS idx_ayahs_search_tafsir;
+
+--SEPARATOR--
+
+DROP INDEX IF EXISTS idx_ayahs_search_latin;
+
+--SEPARATOR--
+
+DROP INDEX IF EXISTS idx_ayahs_search_translation;
+
+--SEPARATOR--
+
+ALTER TABLE ayahs DROP COLUMN IF EXISTS search_tafsir;
+
+--SEPARATOR--
+
+ALTER TABLE ayahs DROP COLUMN IF EXISTS search_latin;
+
+--SEPARATOR--
+
+ALTER TABLE ayahs DROP COLUMN IF EXISTS search_translation;
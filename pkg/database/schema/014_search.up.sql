@@ -0,0 +1,70 @@
+-- Pencarian teks penuh: kolom tsvector generated (selalu sinkron dengan teksnya) dan GIN index.
+-- Terjemahan DefaultTranslation dan tafsir di tabel ayahs berbahasa Indonesia; transliterasi latin
+-- memakai konfigurasi simple karena bukan bahasa yang bisa di-stem.
+-- Konfigurasi indonesian baru tersedia sejak PostgreSQL 12, jadi migration ini berhenti lebih awal
+-- dengan pesan yang jelas daripada gagal di tengah dengan error regconfig.
+DO $$
+BEGIN
+    IF NOT EXISTS (SELECT 1 FROM pg_catalog.pg_ts_config WHERE cfgname = 'indonesian') THEN
+        RAISE EXCEPTION 'text search configuration "indonesian" not found: PostgreSQL 12 or newer is required (server is %)',
+            current_setting('server_version');
+    END IF;
+END
+$$;
+
+--SEPARATOR--
+
+ALTER TABLE ayahs ADD COLUMN IF NOT EXISTS search_translation tsvector
+    GENERATED ALWAYS AS (to_tsvector('indonesian'::regconfig, coalesce(translation, ''))) STORED;
+
+--SEPARATOR--
+
+ALTER TABLE ayahs ADD COLUMN IF NOT EXISTS search_latin tsvector
+    GENERATED ALWAYS AS (to_tsvector('simple'::regconfig, coalesce(text_latin, ''))) STORED;
+
+--SEPARATOR--
+
+ALTER TABLE ayahs ADD COLUMN IF NOT EXISTS search_tafsir tsvector
+    GENERATED ALWAYS AS (to_tsvector('indonesian'::regconfig, coalesce(tafsir, ''))) STORED;
+
+--SEPARATOR--
+
+CREATE INDEX IF NOT EXISTS idx_ayahs_search_translation ON ayahs USING GIN (search_translation);
+
+--SEPARATOR--
+
+CREATE INDEX IF NOT EXISTS idx_ayahs_search_latin ON ayahs USING GIN (search_latin);
+
+--SEPARATOR--
+
+CREATE INDEX IF NOT EXISTS idx_ayahs_search_tafsir ON ayahs USING GIN (search_tafsir);
+
+--SEPARATOR--
+
+-- Kolom generated tidak bisa membaca tabel translations, jadi konfigurasi dipilih dari prefix
+-- kode edisi (id.kemenag -> indonesian, en.sahih -> english, lainnya simple)
+ALTER TABLE ayah_translations ADD COLUMN IF NOT EXISTS search_vector tsvector
+    GENERATED ALWAYS AS (to_tsvector(
+        CASE split_part(edition, '.', 1)
+            WHEN 'id' THEN 'indonesian'::regconfig
+            WHEN 'en' THEN 'english'::regconfig
+            ELSE 'simple'::regconfig
+        END, coalesce(text, ''))) STORED;
+
+--SEPARATOR--
+
+CREATE INDEX IF NOT EXISTS idx_ayah_translations_search ON ayah_translations USING GIN (search_vector);
+
+--SEPARATOR--
+
+ALTER TABLE tafsir_entries ADD COLUMN IF NOT EXISTS search_vector tsvector
+    GENERATED ALWAYS AS (to_tsvector(
+        CASE split_part(edition, '.', 1)
+            WHEN 'id' THEN 'indonesian'::regconfig
+            WHEN 'en' THEN 'english'::regconfig
+            ELSE 'simple'::regconfig
+        END, coalesce(text, ''))) STORED;
+
+--SEPARATOR--
+
+CREATE INDEX IF NOT EXISTS idx_tafsir_entries_search ON tafsir_entries USING GIN (search_vector);
@@ -0,0 +1,9 @@
+DROP INDEX IF EXISTS idx_ayahs_search_arabic;
+
+--SEPARATOR--
+
+ALTER TABLE ayahs DROP COLUMN IF EXISTS search_arabic;
+
+--SEPARATOR--
+
+ALTER TABLE ayahs DROP COLUMN IF EXISTS text_normalized;
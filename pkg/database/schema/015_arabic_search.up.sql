@@ -0,0 +1,12 @@
+-- Teks Arab tanpa harakat untuk pencarian. Normalisasi dilakukan di Go (utils.NormalizeArabic) saat seeding
+-- dan import, dengan fungsi yang sama seperti saat menerima query, sehingga hasilnya selalu konsisten.
+ALTER TABLE ayahs ADD COLUMN IF NOT EXISTS text_normalized TEXT NOT NULL DEFAULT '';
+
+--SEPARATOR--
+
+ALTER TABLE ayahs ADD COLUMN IF NOT EXISTS search_arabic tsvector
+    GENERATED ALWAYS AS (to_tsvector('simple'::regconfig, text_normalized)) STORED;
+
+--SEPARATOR--
+
+CREATE INDEX IF NOT EXISTS idx_ayahs_search_arabic ON ayahs USING GIN (search_arabic);
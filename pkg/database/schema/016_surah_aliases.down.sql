@@ -0,0 +1,6 @@
+DROP INDEX IF EXISTS idx_surah_aliases_search_key;
+
+--SEPARATOR--
+
+-- Extension pg_trgm dibiarkan karena bisa dipakai di luar aplikasi ini
+DROP TABLE IF EXISTS surah_aliases;
\ No newline at end of file
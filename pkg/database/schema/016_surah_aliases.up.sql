@@ -0,0 +1,20 @@
+-- Pencocokan nama surah yang tahan salah ketik memakai similarity trigram. Extension selalu dipasang di
+-- skema public dan objeknya dirujuk dengan nama lengkap, agar tetap bisa dipakai dari search_path lain
+-- (misal replay migrasi di `server schema check`).
+CREATE EXTENSION IF NOT EXISTS pg_trgm WITH SCHEMA public;
+
+--SEPARATOR--
+
+-- Ejaan nama surah yang bisa dicari: nama di tabel surahs ditambah alias dari surah_aliases.json.
+-- search_key diisi di Go (utils.SurahNameKey) dengan fungsi yang sama seperti saat menerima query.
+CREATE TABLE IF NOT EXISTS surah_aliases (
+    surah_id INT NOT NULL,
+    alias VARCHAR(100) NOT NULL,
+    search_key VARCHAR(100) NOT NULL,
+    PRIMARY KEY (surah_id, alias),
+    CONSTRAINT fk_surah_alias_surah FOREIGN KEY (surah_id) REFERENCES surahs(number) ON DELETE CASCADE
+);
+
+--SEPARATOR--
+
+CREATE INDEX IF NOT EXISTS idx_surah_aliases_search_key ON surah_aliases USING GIN (search_key public.gin_trgm_ops);
\ No newline at end of file
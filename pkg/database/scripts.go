@@ -0,0 +1,237 @@
+package database
+
+import (
+	"fmt"
+	"io/fs"
+	"path"
+	"sort"
+	"strings"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	"khalif-alquran/internal/domain"
+
+)
+
+// Edisi rasm selain DefaultScript disimpan di scripts/<script>.txt dengan format teks Tanzil
+// (sura|aya|text), misal scripts/imlaei.txt dari quran-simple.txt tanzil.net. Folder ini opsional.
+const seedScriptPattern = "scripts/*.txt"
+
+// isExtraScript bernilai true untuk edisi rasm yang disimpan di ayah_scripts
+func isExtraScript(script string) bool {
+	return script == domain.ScriptImlaei || script == domain.ScriptIndopak
+}
+
+// loadSeedScripts membaca semua file edisi rasm, dikelompokkan per nama edisi
+func loadSeedScripts(source fs.FS, counts map[int]int) (map[string][]domain.AyahScript, error) {
+	files, err := fs.Glob(source, seedScriptPattern)
+	if err != nil {
+		return nil, err
+	}
+	if len(files) == 0 {
+		return nil, nil
+	}
+
+	result := make(map[string][]domain.AyahScript, len(files))
+	for _, filename := range files {
+		script := strings.TrimSuffix(path.Base(filename), ".txt")
+		if !isExtraScript(script) {
+			return nil, fmt.Errorf("%s: unknown script %q, expected %s or %s", filename, script, domain.ScriptImlaei, domain.ScriptIndopak)
+		}
+
+		f, err := source.Open(filename)
+		if err != nil {
+			return nil, fmt.Errorf("read %s: %w", filename, err)
+		}
+		verses, err := ParseTanzilText(f)
+		f.Close()
+		if err != nil {
+			return nil, fmt.Errorf("parse %s: %w", filename, err)
+		}
+
+		rows := make([]domain.AyahScript, 0, len(verses))
+		for _, v := range verses {
+			if count, ok := counts[v.Surah]; !ok || v.Ayah > count {
+				return nil, fmt.Errorf("%s: %d:%d is not in the surah catalog", filename, v.Surah, v.Ayah)
+			}
+			rows = append(rows, domain.AyahScript{
+				Script:     script,
+				SurahID:    uint(v.Surah),
+				AyahNumber: v.Ayah,
+				Text:       v.Text,
+			})
+		}
+		result[script] = rows
+	}
+
+	return result, nil
+}
+
+// syncScripts menyamakan teks setiap edisi rasm yang punya file seed.
+// Ayat yang belum ada di tabel ayahs dilewati karena foreign key. Tanpa overwrite hanya teks
+// yang belum ada yang ditambah.
+func syncScripts(tx *gorm.DB, seed map[string][]domain.AyahScript, overwrite bool) (int, error) {
+	ayahs, err := ayahKeys(tx)
+	if err != nil {
+		return 0, err
+	}
+
+	scripts := make([]string, 0, len(seed))
+	for script := range seed {
+		scripts = append(scripts, script)
+	}
+	sort.Strings(scripts)
+
+	changed := 0
+	for _, script := range scripts {
+		var present map[[2]int]bool
+		if !overwrite {
+			if present, err = editionKeys(tx, &domain.AyahScript{}, "script", script); err != nil {
+				return 0, err
+			}
+		}
+
+		var rows []domain.AyahScript
+		for _, row := range seed[script] {
+			key := [2]int{int(row.SurahID), row.AyahNumber}
+			if ayahs[key] && !present[key] {
+				rows = append(rows, row)
+			}
+		}
+
+		n, err := mergeAyahScripts(tx, script, rows, overwrite)
+		if err != nil {
+			return 0, err
+		}
+		changed += n
+	}
+
+	return changed, nil
+}
+
+// mergeAyahScripts menulis teks satu edisi rasm: baris yang berbeda di-upsert, dan jika prune
+// bernilai true, baris yang tidak ada di rows dihapus. Mengembalikan jumlah baris yang berubah.
+func mergeAyahScripts(tx *gorm.DB, script string, rows []domain.AyahScript, prune bool) (int, error) {
+	var current []domain.AyahScript
+	if err := tx.Where("script = ?", script).Find(&current).Error; err != nil {
+		return 0, err
+	}
+
+	existing := make(map[[2]int]string, len(current))
+	for _, row := range current {
+		existing[[2]int{int(row.SurahID), row.AyahNumber}] = row.Text
+	}
+
+	var upserts []domain.AyahScript
+	for _, row := range rows {
+		key := [2]int{int(row.SurahID), row.AyahNumber}
+		text, ok := existing[key]
+		delete(existing, key)
+		if ok && text == row.Text {
+			continue
+		}
+		upserts = append(upserts, row)
+	}
+
+	changed := 0
+	if len(upserts) > 0 {
+		if err := tx.Clauses(clause.OnConflict{UpdateAll: true}).CreateInBatches(upserts, 500).Error; err != nil {
+			return 0, fmt.Errorf("upsert script %s: %w", script, err)
+		}
+		changed += len(upserts)
+	}
+
+	if prune {
+		for key := range existing {
+			err := tx.Where("script = ? AND surah_id = ? AND ayah_number = ?", script, key[0], key[1]).
+				Delete(&domain.AyahScript{}).Error
+			if err != nil {
+				return 0, fmt.Errorf("delete script %s %d:%d: %w", script, key[0], key[1], err)
+			}
+			changed++
+		}
+	}
+
+	return changed, nil
+}
+
+// ImportTanzilScript menggabungkan file teks Tanzil ke ayah_scripts untuk satu edisi rasm
+// selain DefaultScript. Ayat yang belum ada di tabel ayahs dilewati.
+func ImportTanzilScript(db *gorm.DB, verses []TanzilVerse, script string) (*ImportReport, error) {
+	if !isExtraScript(script) {
+		return nil, fmt.Errorf("unknown script %q, expected %s or %s (%s is stored in ayahs.text_arabic)", script, domain.ScriptImlaei, domain.ScriptIndopak, domain.DefaultScript)
+	}
+
+	report := &ImportReport{Target: ImportTargetArabic, Edition: script, Verses: len(verses)}
+
+	err := db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Exec("SELECT pg_advisory_xact_lock(?)", seedLockKey).Error; err != nil {
+			return err
+		}
+
+		ayahs, err := ayahKeys(tx)
+		if err != nil {
+			return err
+		}
+
+		var current []domain.AyahScript
+		if err := tx.Where("script = ?", script).Find(&current).Error; err != nil {
+			return err
+		}
+		existing := make(map[[2]int]string, len(current))
+		for _, row := range current {
+			existing[[2]int{int(row.SurahID), row.AyahNumber}] = row.Text
+		}
+
+		var rows []domain.AyahScript
+		for _, v := range verses {
+			key := [2]int{v.Surah, v.Ayah}
+			if !ayahs[key] {
+				report.Skipped = append(report.Skipped, fmt.Sprintf("%d:%d", v.Surah, v.Ayah))
+				continue
+			}
+
+			text, ok := existing[key]
+			switch {
+			case !ok:
+				report.Inserted++
+			case text == v.Text:
+				report.Unchanged++
+				continue
+			default:
+				report.Updated++
+			}
+			rows = append(rows, domain.AyahScript{
+				Script:     script,
+				SurahID:    uint(v.Surah),
+				AyahNumber: v.Ayah,
+				Text:       v.Text,
+			})
+		}
+
+		_, err = mergeAyahScripts(tx, script, rows, false)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return report, nil
+}
+
+// encodeSeedScriptText menulis teks satu edisi rasm dalam format teks Tanzil
+func encodeSeedScriptText(rows []domain.AyahScript) []byte {
+	sort.Slice(rows, func(i, j int) bool {
+		if rows[i].SurahID != rows[j].SurahID {
+			return rows[i].SurahID < rows[j].SurahID
+		}
+		return rows[i].AyahNumber < rows[j].AyahNumber
+	})
+
+	lines := make([]string, 0, len(rows))
+	for _, row := range rows {
+		lines = append(lines, fmt.Sprintf("%d|%d|%s", row.SurahID, row.AyahNumber, row.Text))
+	}
+	return []byte(strings.Join(lines, "\r\n"))
+}
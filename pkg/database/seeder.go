@@ -1,62 +1,595 @@
 package database
 
 import (
+	"embed"
 	"encoding/json"
+	"fmt"
+	"io/fs"
 	"os"
-	"path/filepath"
+	"reflect"
+	"sort"
 
 	"go.uber.org/zap"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 
 	"khalif-alquran/internal/domain"
 	"khalif-alquran/pkg/logger"
+	"khalif-alquran/pkg/utils"
 
 )
 
-func SeedQuran(db *gorm.DB) {
-	var count int64
-	db.Model(&domain.Surah{}).Count(&count)
-	if count > 0 {
-		logger.Info("Surahs already seeded, skipping...")
-		return
+// Corpus Al-Quran di-embed agar satu binary statis bisa berjalan offline tanpa folder seeds
+//go:embed seeds
+var seedFS embed.FS
+
+// Kunci advisory lock agar dua instance tidak melakukan seeding bersamaan
+const seedLockKey = 7_283_115
+
+const (
+	SeedInserted  = "inserted"
+	SeedUpdated   = "updated"
+	SeedUnchanged = "unchanged"
+)
+
+// SurahSeedResult adalah ringkasan hasil seeding untuk satu surah
+type SurahSeedResult struct {
+	Number    int    `json:"number"`
+	LatinName string `json:"latin_name"`
+	Surah     string `json:"surah"` // status baris metadata surah: inserted | updated | unchanged
+	Inserted  int    `json:"ayahs_inserted"`
+	Updated   int    `json:"ayahs_updated"`
+	Unchanged int    `json:"ayahs_unchanged"`
+}
+
+type SeedReport struct {
+	Surahs      []SurahSeedResult `json:"surahs"`
+	Inserted    int               `json:"ayahs_inserted"`
+	Updated     int               `json:"ayahs_updated"`
+	Unchanged   int               `json:"ayahs_unchanged"`
+	Renumbered  int               `json:"ayahs_renumbered"`     // number_in_quran yang dihitung ulang
+	AudioFilled int               `json:"audio_urls_filled"`    // audio_url kosong yang diisi dari template
+	Divisions   int               `json:"divisions_changed"`    // baris divisions/sajdahs yang berubah
+	Annotated   int               `json:"ayahs_annotated"`      // ayat yang juz/hizb/page-nya diperbarui
+	Mushaf      int               `json:"mushaf_changed"`       // layout mushaf yang ditulis ulang atau dihapus
+	Translation int               `json:"translations_changed"` // baris katalog/teks terjemahan yang berubah
+	Tafsir      int               `json:"tafsir_changed"`       // entri tafsir yang ditambah, diubah atau dihapus
+	Words       int               `json:"words_changed"`        // baris kata per kata yang berubah
+	Scripts     int               `json:"scripts_changed"`      // baris teks edisi rasm (imlaei/indopak) yang berubah
+	Riwayah     int               `json:"riwayah_changed"`      // ayat riwayat selain Hafs yang ditambah, diubah atau dihapus
+	Tajwid      int               `json:"tajwid_changed"`       // baris katalog hukum tajwid dan anotasi tajwid yang berubah
+	Normalized  int               `json:"ayahs_normalized"`     // ayat yang teks Arab tanpa harakatnya (untuk pencarian) diperbarui
+	Aliases     int               `json:"aliases_changed"`      // nama dan alias surah untuk pencarian yang berubah
+}
+
+// Seed menulis seluruh data seed dalam satu transaksi. Jika satu file gagal, tidak ada yang tersimpan.
+// Baris dicocokkan berdasarkan (nomor surah, nomor ayat), sehingga aman dijalankan ulang.
+// Jika overwrite bernilai true (`seed --overwrite`), isi database disamakan dengan file seed: baris
+// yang berbeda ditimpa dan baris yang tidak ada di file dihapus, untuk menerapkan koreksi teks.
+// Jika false (saat boot dan `seed` biasa), hanya baris yang belum ada yang ditambah dan kolom
+// yang masih kosong yang diisi, sehingga hasil `import` dan suntingan di database tidak tertimpa.
+// number_in_quran dan audio_url (dari audioURLTemplate) diisi untuk ayat yang belum memilikinya.
+func Seed(db *gorm.DB, source fs.FS, audioURLTemplate string, overwrite bool) (*SeedReport, error) {
+	surahs, err := loadSeedSurahs(source)
+	if err != nil {
+		return nil, err
+	}
+
+	counts := make(map[int]int, len(surahs))
+	for _, surah := range surahs {
+		counts[surah.Number] = surah.TotalAyahs
+	}
+
+	layouts, err := loadSeedMushaf(source, counts)
+	if err != nil {
+		return nil, err
+	}
+
+	divisions, sajdahs, err := loadSeedDivisions(source, counts, defaultMushafPages(layouts))
+	if err != nil {
+		return nil, err
+	}
+
+	translations, err := loadSeedTranslations(source, counts)
+	if err != nil {
+		return nil, err
+	}
+
+	tafsir, err := loadSeedTafsir(source, counts)
+	if err != nil {
+		return nil, err
+	}
+
+	words, err := loadSeedWords(source, counts)
+	if err != nil {
+		return nil, err
+	}
+
+	scripts, err := loadSeedScripts(source, counts)
+	if err != nil {
+		return nil, err
+	}
+
+	riwayat, err := loadSeedRiwayat(source, counts)
+	if err != nil {
+		return nil, err
 	}
 
-	// Sesuaikan path dengan lokasi file di Docker
-	pattern := "pkg/database/seeds/data/*.json"
-	files, err := filepath.Glob(pattern)
+	tajwidRules, err := loadSeedTajwidRules(source)
+	if err != nil {
+		return nil, err
+	}
+
+	aliases, err := loadSeedSurahAliases(source, counts)
+	if err != nil {
+		return nil, err
+	}
+
+	report := &SeedReport{}
+	offsets := catalogOffsets(surahs)
+
+	err = db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Exec("SELECT pg_advisory_xact_lock(?)", seedLockKey).Error; err != nil {
+			return err
+		}
+
+		for _, surah := range surahs {
+			result, err := upsertSurah(tx, surah, offsets[surah.Number], overwrite)
+			if err != nil {
+				return fmt.Errorf("surah %d (%s): %w", surah.Number, surah.LatinName, err)
+			}
+
+			report.Surahs = append(report.Surahs, result)
+			report.Inserted += result.Inserted
+			report.Updated += result.Updated
+			report.Unchanged += result.Unchanged
+		}
+
+		// Ayat di luar file seed (misal hasil import Tanzil) ikut dinomori ulang dari katalog
+		renumbered, err := renumberAyahs(tx)
+		if err != nil {
+			return fmt.Errorf("renumber ayahs: %w", err)
+		}
+		report.Renumbered = int(renumbered)
+
+		filled, err := fillAudioURLs(tx, audioURLTemplate)
+		if err != nil {
+			return fmt.Errorf("fill audio urls: %w", err)
+		}
+		report.AudioFilled = int(filled)
+
+		normalized, err := normalizeAyahs(tx)
+		if err != nil {
+			return fmt.Errorf("normalize ayahs: %w", err)
+		}
+		report.Normalized = normalized
+
+		// Nama surah selalu disinkronkan agar tetap bisa dicari meski surah_aliases.json tidak ada
+		changed, err := syncSurahAliases(tx, surahAliasRows(surahs, aliases), overwrite && aliases != nil)
+		if err != nil {
+			return fmt.Errorf("sync surah aliases: %w", err)
+		}
+		report.Aliases = changed
+
+		if layouts != nil {
+			changed, err := syncMushaf(tx, layouts, overwrite)
+			if err != nil {
+				return err
+			}
+			report.Mushaf = changed
+		}
+
+		if divisions != nil {
+			changed, err := syncDivisions(tx, divisions, sajdahs, overwrite)
+			if err != nil {
+				return err
+			}
+			report.Divisions = changed
+		}
+
+		// Setelah ayat di-upsert, karena ayah_translations merujuk ke tabel ayahs
+		if translations != nil {
+			changed, err := syncTranslations(tx, translations, overwrite)
+			if err != nil {
+				return fmt.Errorf("sync translations: %w", err)
+			}
+			report.Translation = changed
+		}
+
+		if len(tafsir) > 0 {
+			changed, err := syncTafsir(tx, tafsir, counts, overwrite)
+			if err != nil {
+				return fmt.Errorf("sync tafsir: %w", err)
+			}
+			report.Tafsir = changed
+		}
+
+		if words != nil {
+			changed, err := syncWords(tx, words, overwrite)
+			if err != nil {
+				return fmt.Errorf("sync words: %w", err)
+			}
+			report.Words = changed
+		}
+
+		if scripts != nil {
+			changed, err := syncScripts(tx, scripts, overwrite)
+			if err != nil {
+				return fmt.Errorf("sync scripts: %w", err)
+			}
+			report.Scripts = changed
+		}
+
+		if len(riwayat) > 0 {
+			changed, err := syncRiwayat(tx, riwayat, counts, overwrite)
+			if err != nil {
+				return fmt.Errorf("sync riwayah: %w", err)
+			}
+			report.Riwayah = changed
+		}
+
+		if tajwidRules != nil {
+			changed, err := syncTajwidRules(tx, tajwidRules, overwrite)
+			if err != nil {
+				return fmt.Errorf("sync tajwid rules: %w", err)
+			}
+			report.Tajwid = changed
+		}
+
+		// Offset anotasi bergantung pada text_arabic, jadi dihitung ulang setelah ayat di-upsert
+		rebuilt, err := rebuildTajwid(tx)
+		if err != nil {
+			return fmt.Errorf("rebuild tajwid annotations: %w", err)
+		}
+		report.Tajwid += rebuilt
+
+		annotated, err := annotateAyahs(tx)
+		if err != nil {
+			return fmt.Errorf("annotate ayahs: %w", err)
+		}
+		report.Annotated = int(annotated)
+		return nil
+	})
 	if err != nil {
-		logger.Error("Failed to list seed files", zap.Error(err))
-		return
+		return nil, err
 	}
 
-	if len(files) == 0 {
-		// PERBAIKAN: Ganti logger.Warn jadi logger.Info
-		logger.Info("No seed files found in " + pattern) 
-		return
+	return report, nil
+}
+
+// SeedQuran dipanggil saat boot. Hanya data yang belum ada yang ditulis (Seed tanpa overwrite);
+// koreksi dari file seed diterapkan lewat `seed --overwrite`. Kegagalan seeding dicatat tanpa
+// menghentikan server, karena transaksi sudah menjamin database tidak setengah terisi.
+func SeedQuran(db *gorm.DB, source fs.FS, audioURLTemplate string) *SeedReport {
+	report, err := Seed(db, source, audioURLTemplate, false)
+	if err != nil {
+		logger.Error("Database seeding failed, nothing was written", zap.Error(err))
+		return nil
 	}
 
-	logger.Info("Start seeding Surahs...", zap.Int("files_found", len(files)))
+	for _, s := range report.Surahs {
+		if s.Surah == SeedUnchanged && s.Inserted == 0 && s.Updated == 0 {
+			continue
+		}
+		logger.Info("Seeded: "+s.LatinName,
+			zap.Int("surah", s.Number),
+			zap.String("metadata", s.Surah),
+			zap.Int("inserted", s.Inserted),
+			zap.Int("updated", s.Updated),
+			zap.Int("unchanged", s.Unchanged),
+		)
+	}
 
+	logger.Info("Database seeding completed.",
+		zap.Int("surahs", len(report.Surahs)),
+		zap.Int("inserted", report.Inserted),
+		zap.Int("updated", report.Updated),
+		zap.Int("unchanged", report.Unchanged),
+		zap.Int("renumbered", report.Renumbered),
+		zap.Int("audio_filled", report.AudioFilled),
+		zap.Int("divisions_changed", report.Divisions),
+		zap.Int("annotated", report.Annotated),
+		zap.Int("mushaf_changed", report.Mushaf),
+		zap.Int("translations_changed", report.Translation),
+		zap.Int("tafsir_changed", report.Tafsir),
+		zap.Int("words_changed", report.Words),
+		zap.Int("scripts_changed", report.Scripts),
+		zap.Int("riwayah_changed", report.Riwayah),
+		zap.Int("tajwid_changed", report.Tajwid),
+		zap.Int("normalized", report.Normalized),
+		zap.Int("aliases_changed", report.Aliases),
+	)
+	return report
+}
+
+// Changed bernilai true jika seeding menulis sesuatu, artinya cache API perlu dibuang
+func (r *SeedReport) Changed() bool {
+	if r.Inserted > 0 || r.Updated > 0 || r.Renumbered > 0 || r.AudioFilled > 0 || r.Divisions > 0 || r.Annotated > 0 || r.Mushaf > 0 || r.Translation > 0 || r.Tafsir > 0 || r.Words > 0 || r.Scripts > 0 || r.Riwayah > 0 || r.Tajwid > 0 || r.Normalized > 0 || r.Aliases > 0 {
+		return true
+	}
+	for _, s := range r.Surahs {
+		if s.Surah != SeedUnchanged {
+			return true
+		}
+	}
+	return false
+}
+
+const (
+	seedCatalogFile = "quran.json"
+	seedDataPattern = "data/*.json"
+)
+
+type seedCatalog struct {
+	Surahs []domain.Surah `json:"surahs"`
+}
+
+// SeedSource mengembalikan sumber data seed: folder dir jika diisi (override SEED_DIR),
+// atau corpus yang di-embed ke binary. Struktur folder keduanya sama dengan pkg/database/seeds.
+func SeedSource(dir string) fs.FS {
+	if dir != "" {
+		return os.DirFS(dir)
+	}
+
+	sub, err := fs.Sub(seedFS, "seeds")
+	if err != nil {
+		// Tidak mungkin terjadi selama direktive go:embed di atas valid
+		panic(err)
+	}
+	return sub
+}
+
+// loadSeedSurahs memuat 114 surah dari katalog quran.json (sumber metadata yang kanonik),
+// lalu menempelkan ayat dari file per-surah di data/ jika tersedia
+func loadSeedSurahs(source fs.FS) ([]domain.Surah, error) {
+	catalogData, err := fs.ReadFile(source, seedCatalogFile)
+	if err != nil {
+		return nil, fmt.Errorf("read surah catalog: %w", err)
+	}
+
+	var catalog seedCatalog
+	if err := json.Unmarshal(catalogData, &catalog); err != nil {
+		return nil, fmt.Errorf("parse %s: %w", seedCatalogFile, err)
+	}
+
+	if len(catalog.Surahs) == 0 {
+		return nil, fmt.Errorf("%s contains no surahs", seedCatalogFile)
+	}
+
+	surahs := catalog.Surahs
+	index := make(map[int]int, len(surahs))
+	for i, surah := range surahs {
+		if _, ok := index[surah.Number]; ok {
+			return nil, fmt.Errorf("surah %d is listed twice in %s", surah.Number, seedCatalogFile)
+		}
+		index[surah.Number] = i
+	}
+
+	files, err := fs.Glob(source, seedDataPattern)
+	if err != nil {
+		return nil, err
+	}
+
+	seen := make(map[int]string)
 	for _, filename := range files {
-		fileData, err := os.ReadFile(filename)
+		fileData, err := fs.ReadFile(source, filename)
 		if err != nil {
-			logger.Error("Failed to read seed file", zap.String("file", filename), zap.Error(err))
+			return nil, fmt.Errorf("read %s: %w", filename, err)
+		}
+
+		var content domain.Surah
+		if err := json.Unmarshal(fileData, &content); err != nil {
+			return nil, fmt.Errorf("parse %s: %w", filename, err)
+		}
+
+		if other, ok := seen[content.Number]; ok {
+			return nil, fmt.Errorf("surah %d is defined in both %s and %s", content.Number, other, filename)
+		}
+		seen[content.Number] = filename
+
+		i, ok := index[content.Number]
+		if !ok {
+			return nil, fmt.Errorf("%s: surah %d is not listed in %s", filename, content.Number, seedCatalogFile)
+		}
+
+		// Metadata tetap dari katalog, file per-surah hanya menyumbang ayat
+		surahs[i].Ayahs = content.Ayahs
+	}
+
+	sort.Slice(surahs, func(i, j int) bool {
+		return surahs[i].Number < surahs[j].Number
+	})
+
+	return surahs, nil
+}
+
+// catalogOffsets menghitung jumlah ayat seluruh surah sebelumnya untuk tiap nomor surah,
+// sehingga number_in_quran = offset + nomor ayat
+func catalogOffsets(surahs []domain.Surah) map[int]int {
+	offsets := make(map[int]int, len(surahs))
+	total := 0
+	for _, surah := range surahs {
+		offsets[surah.Number] = total
+		total += surah.TotalAyahs
+	}
+	return offsets
+}
+
+// surahOffsets sama dengan catalogOffsets, tetapi dari tabel surahs di database
+func surahOffsets(tx *gorm.DB) (map[int]int, error) {
+	var rows []struct {
+		Number     int
+		AyahOffset int
+	}
+	err := tx.Raw("SELECT number, SUM(total_ayahs) OVER (ORDER BY number) - total_ayahs AS ayah_offset FROM surahs").
+		Scan(&rows).Error
+	if err != nil {
+		return nil, err
+	}
+
+	offsets := make(map[int]int, len(rows))
+	for _, row := range rows {
+		offsets[row.Number] = row.AyahOffset
+	}
+	return offsets, nil
+}
+
+// renumberAyahs memperbaiki number_in_quran yang tidak sesuai dengan katalog surah,
+// misalnya baris lama yang diisi saat katalog di database belum lengkap
+func renumberAyahs(tx *gorm.DB) (int64, error) {
+	res := tx.Exec(`UPDATE ayahs a
+		SET number_in_quran = o.ayah_offset + a.number
+		FROM (SELECT number, SUM(total_ayahs) OVER (ORDER BY number) - total_ayahs AS ayah_offset FROM surahs) o
+		WHERE o.number = a.surah_id AND a.number_in_quran IS DISTINCT FROM o.ayah_offset + a.number`)
+	return res.RowsAffected, res.Error
+}
+
+// fillAudioURLs mengisi audio_url yang kosong dari template. Placeholder: {surah} dan {ayah}
+// (3 digit, gaya everyayah.com) serta {global} untuk number_in_quran. URL yang sudah ada tidak diubah.
+func fillAudioURLs(tx *gorm.DB, template string) (int64, error) {
+	if template == "" {
+		return 0, nil
+	}
+
+	res := tx.Exec(`UPDATE ayahs
+		SET audio_url = replace(replace(replace(?::text,
+			'{surah}', lpad(surah_id::text, 3, '0')),
+			'{ayah}', lpad(number::text, 3, '0')),
+			'{global}', number_in_quran::text)
+		WHERE audio_url IS NULL OR audio_url = ''`, template)
+	return res.RowsAffected, res.Error
+}
+
+// normalizeAyahs mengisi text_normalized (utils.NormalizeArabic dari text_arabic) untuk ayat yang
+// belum diisi, teks Arabnya berubah, atau aturan normalisasinya berubah. Mengembalikan jumlah ayat
+// yang diperbarui.
+func normalizeAyahs(tx *gorm.DB) (int, error) {
+	var ayahs []domain.Ayah
+	if err := tx.Select("id", "text_arabic", "text_normalized").Find(&ayahs).Error; err != nil {
+		return 0, err
+	}
+
+	changed := 0
+	for _, ayah := range ayahs {
+		normalized := utils.NormalizeArabic(ayah.TextArabic)
+		if normalized == ayah.TextNormalized {
 			continue
 		}
+		if err := tx.Model(&domain.Ayah{}).Where("id = ?", ayah.ID).Update("text_normalized", normalized).Error; err != nil {
+			return 0, fmt.Errorf("normalize ayah %d: %w", ayah.ID, err)
+		}
+		changed++
+	}
+	return changed, nil
+}
+
+// upsertSurah menulis metadata dan ayat satu surah. Tanpa overwrite, baris yang sudah ada hanya
+// dilengkapi pada kolom yang masih kosong (lihat surahUpdates dan ayahUpdates).
+func upsertSurah(tx *gorm.DB, surah domain.Surah, offset int, overwrite bool) (SurahSeedResult, error) {
+	result := SurahSeedResult{Number: surah.Number, LatinName: surah.LatinName}
 
-		var surah domain.Surah
-		if err := json.Unmarshal(fileData, &surah); err != nil {
-			logger.Error("Failed to parse json", zap.String("file", filename), zap.Error(err))
+	var existing domain.Surah
+	err := tx.Where("number = ?", surah.Number).Limit(1).Find(&existing).Error
+	if err != nil {
+		return result, err
+	}
+
+	if existing.Number == 0 {
+		if err := tx.Omit(clause.Associations).Create(&surah).Error; err != nil {
+			return result, err
+		}
+		result.Surah = SeedInserted
+	} else if fields := surahUpdates(existing, surah, overwrite); len(fields) > 0 {
+		if err := tx.Model(&domain.Surah{}).Where("number = ?", surah.Number).Updates(fields).Error; err != nil {
+			return result, err
+		}
+		result.Surah = SeedUpdated
+	} else {
+		result.Surah = SeedUnchanged
+	}
+
+	var current []domain.Ayah
+	if err := tx.Where("surah_id = ?", surah.Number).Find(&current).Error; err != nil {
+		return result, err
+	}
+
+	byNumber := make(map[int]domain.Ayah, len(current))
+	for _, ayah := range current {
+		byNumber[ayah.Number] = ayah
+	}
+
+	var inserts []domain.Ayah
+	for _, ayah := range surah.Ayahs {
+		ayah.SurahID = uint(surah.Number)
+		ayah.NumberInQuran = offset + ayah.Number
+
+		old, ok := byNumber[ayah.Number]
+		if !ok {
+			inserts = append(inserts, ayah)
 			continue
 		}
 
-		if err := db.Create(&surah).Error; err != nil {
-			logger.Error("Failed to insert surah to DB", zap.String("surah", surah.Name), zap.Error(err))
-			return
+		fields := ayahUpdates(old, ayah, overwrite)
+		if len(fields) == 0 {
+			result.Unchanged++
+			continue
 		}
 
-		logger.Info("Seeded: " + surah.LatinName)
+		if err := tx.Model(&domain.Ayah{}).Where("id = ?", old.ID).Updates(fields).Error; err != nil {
+			return result, fmt.Errorf("update ayah %d: %w", ayah.Number, err)
+		}
+		result.Updated++
 	}
 
-	logger.Info("Database seeding completed.")
-}
\ No newline at end of file
+	if len(inserts) > 0 {
+		if err := tx.Omit(clause.Associations).CreateInBatches(inserts, 100).Error; err != nil {
+			return result, fmt.Errorf("insert ayahs: %w", err)
+		}
+		result.Inserted = len(inserts)
+	}
+
+	return result, nil
+}
+
+// seedFields mengumpulkan kolom yang perlu ditulis: kolom yang nilainya berbeda jika overwrite,
+// atau hanya kolom yang masih kosong di database jika tidak
+type seedFields struct {
+	overwrite bool
+	values    map[string]interface{}
+}
+
+func (f *seedFields) set(column string, changed, empty bool, value interface{}) {
+	if changed && (f.overwrite || empty) {
+		f.values[column] = value
+	}
+}
+
+func surahUpdates(old, next domain.Surah, overwrite bool) map[string]interface{} {
+	f := seedFields{overwrite: overwrite, values: make(map[string]interface{})}
+	f.set("name", old.Name != next.Name, old.Name == "", next.Name)
+	f.set("latin_name", old.LatinName != next.LatinName, old.LatinName == "", next.LatinName)
+	f.set("english_name", old.EnglishName != next.EnglishName, old.EnglishName == "", next.EnglishName)
+	f.set("indonesian_name", old.IndonesianName != next.IndonesianName, old.IndonesianName == "", next.IndonesianName)
+	f.set("revelation_type", old.RevelationType != next.RevelationType, old.RevelationType == "", next.RevelationType)
+	f.set("total_ayahs", old.TotalAyahs != next.TotalAyahs, old.TotalAyahs == 0, next.TotalAyahs)
+	return f.values
+}
+
+func ayahUpdates(old, next domain.Ayah, overwrite bool) map[string]interface{} {
+	f := seedFields{overwrite: overwrite, values: make(map[string]interface{})}
+	f.set("text_arabic", old.TextArabic != next.TextArabic, old.TextArabic == "", next.TextArabic)
+	f.set("text_latin", old.TextLatin != next.TextLatin, old.TextLatin == "", next.TextLatin)
+	f.set("translation", old.Translation != next.Translation, old.Translation == "", next.Translation)
+	f.set("tafsir", old.Tafsir != next.Tafsir, old.Tafsir == "", next.Tafsir)
+	f.set("asbabun_nuzul", old.AsbabunNuzul != next.AsbabunNuzul, old.AsbabunNuzul == "", next.AsbabunNuzul)
+	f.set("tajwid_info", !tajwidEqual(old.TajwidInfo, next.TajwidInfo), len(old.TajwidInfo) == 0, next.TajwidInfo)
+	return f.values
+}
+
+// tajwidEqual menganggap nil dan slice kosong sama, karena keduanya berarti "tidak ada data tajwid"
+func tajwidEqual(a, b domain.TajwidList) bool {
+	if len(a) == 0 && len(b) == 0 {
+		return true
+	}
+	return reflect.DeepEqual(a, b)
+}
@@ -0,0 +1,203 @@
+package database
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"sort"
+	"strings"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	"khalif-alquran/internal/domain"
+	"khalif-alquran/pkg/utils"
+
+)
+
+// Ejaan alternatif nama surah, misal "Al Baqoroh" atau "Bani Israil". Nama di quran.json tidak perlu
+// ditulis ulang karena selalu ikut dicari. File ini opsional.
+const seedSurahAliasFile = "surah_aliases.json"
+
+// Panjang maksimum alias, sama dengan kolom surah_aliases.alias
+const maxSurahAliasLength = 100
+
+type seedSurahAliases struct {
+	Surahs []struct {
+		Number  int      `json:"number"`
+		Aliases []string `json:"aliases"`
+	} `json:"surahs"`
+}
+
+// loadSeedSurahAliases membaca surah_aliases.json dan mengembalikan alias per nomor surah.
+// Hasilnya nil jika file tidak ada.
+func loadSeedSurahAliases(source fs.FS, counts map[int]int) (map[int][]string, error) {
+	data, err := fs.ReadFile(source, seedSurahAliasFile)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("read %s: %w", seedSurahAliasFile, err)
+	}
+
+	var file seedSurahAliases
+	if err := json.Unmarshal(data, &file); err != nil {
+		return nil, fmt.Errorf("parse %s: %w", seedSurahAliasFile, err)
+	}
+
+	aliases := make(map[int][]string, len(file.Surahs))
+	for _, s := range file.Surahs {
+		if _, ok := counts[s.Number]; !ok {
+			return nil, fmt.Errorf("%s: surah %d is not in the catalog", seedSurahAliasFile, s.Number)
+		}
+		if _, ok := aliases[s.Number]; ok {
+			return nil, fmt.Errorf("%s: surah %d is listed more than once", seedSurahAliasFile, s.Number)
+		}
+
+		seen := make(map[string]bool, len(s.Aliases))
+		for _, alias := range s.Aliases {
+			if strings.TrimSpace(alias) != alias || alias == "" {
+				return nil, fmt.Errorf("%s: surah %d has an empty alias or one with surrounding spaces", seedSurahAliasFile, s.Number)
+			}
+			if len([]rune(alias)) > maxSurahAliasLength {
+				return nil, fmt.Errorf("%s: surah %d alias %q is longer than %d characters", seedSurahAliasFile, s.Number, alias, maxSurahAliasLength)
+			}
+			if utils.SurahNameKey(alias) == "" {
+				return nil, fmt.Errorf("%s: surah %d alias %q has no searchable letters", seedSurahAliasFile, s.Number, alias)
+			}
+			if seen[alias] {
+				return nil, fmt.Errorf("%s: surah %d alias %q is listed more than once", seedSurahAliasFile, s.Number, alias)
+			}
+			seen[alias] = true
+		}
+		aliases[s.Number] = s.Aliases
+	}
+	return aliases, nil
+}
+
+// surahNames adalah nama-nama surah di katalog yang selalu bisa dicari
+func surahNames(surah domain.Surah) []string {
+	return []string{surah.Name, surah.LatinName, surah.EnglishName, surah.IndonesianName}
+}
+
+// surahAliasRows menyusun isi tabel surah_aliases: nama-nama surah dari katalog ditambah alias dari file
+func surahAliasRows(surahs []domain.Surah, aliases map[int][]string) []domain.SurahAlias {
+	var rows []domain.SurahAlias
+	for _, surah := range surahs {
+		seen := make(map[string]bool)
+		for _, alias := range append(surahNames(surah), aliases[surah.Number]...) {
+			key := utils.SurahNameKey(alias)
+			if alias == "" || key == "" || seen[alias] {
+				continue
+			}
+			seen[alias] = true
+			rows = append(rows, domain.SurahAlias{SurahID: surah.Number, Alias: alias, SearchKey: key})
+		}
+	}
+	return rows
+}
+
+// syncSurahAliases menyamakan tabel surah_aliases dengan rows. Baris di database yang tidak ada di rows
+// hanya dihapus jika prune bernilai true (surah_aliases.json ada), sehingga tanpa file tersebut
+// alias yang sudah tersimpan dibiarkan.
+func syncSurahAliases(tx *gorm.DB, rows []domain.SurahAlias, prune bool) (int, error) {
+	type key struct {
+		surah int
+		alias string
+	}
+
+	var current []domain.SurahAlias
+	if err := tx.Find(&current).Error; err != nil {
+		return 0, err
+	}
+	existing := make(map[key]string, len(current))
+	for _, a := range current {
+		existing[key{a.SurahID, a.Alias}] = a.SearchKey
+	}
+
+	var upserts []domain.SurahAlias
+	for _, a := range rows {
+		k := key{a.SurahID, a.Alias}
+		searchKey, ok := existing[k]
+		delete(existing, k)
+		if ok && searchKey == a.SearchKey {
+			continue
+		}
+		upserts = append(upserts, a)
+	}
+
+	changed := 0
+	if len(upserts) > 0 {
+		if err := tx.Clauses(clause.OnConflict{UpdateAll: true}).CreateInBatches(upserts, 500).Error; err != nil {
+			return 0, fmt.Errorf("upsert surah aliases: %w", err)
+		}
+		changed += len(upserts)
+	}
+
+	if !prune {
+		return changed, nil
+	}
+	for k := range existing {
+		if err := tx.Where("surah_id = ? AND alias = ?", k.surah, k.alias).Delete(&domain.SurahAlias{}).Error; err != nil {
+			return 0, fmt.Errorf("delete surah alias %d (%s): %w", k.surah, k.alias, err)
+		}
+		changed++
+	}
+	return changed, nil
+}
+
+// encodeSeedSurahAliases menulis surah_aliases.json, satu surah per baris. Nama surah dari katalog
+// tidak ditulis karena sudah ada di quran.json. Hasilnya nil jika tidak ada alias tambahan.
+func encodeSeedSurahAliases(surahs []domain.Surah, rows []domain.SurahAlias) ([]byte, error) {
+	names := make(map[int]map[string]bool, len(surahs))
+	for _, surah := range surahs {
+		names[surah.Number] = make(map[string]bool)
+		for _, name := range surahNames(surah) {
+			names[surah.Number][name] = true
+		}
+	}
+
+	aliases := make(map[int][]string)
+	var numbers []int
+	for _, a := range rows {
+		if names[a.SurahID][a.Alias] {
+			continue
+		}
+		if _, ok := aliases[a.SurahID]; !ok {
+			numbers = append(numbers, a.SurahID)
+		}
+		aliases[a.SurahID] = append(aliases[a.SurahID], a.Alias)
+	}
+	if len(numbers) == 0 {
+		return nil, nil
+	}
+	sort.Ints(numbers)
+
+	var buf bytes.Buffer
+	buf.WriteString("{\r\n  \"surahs\": [\r\n")
+
+	for i, number := range numbers {
+		list := aliases[number]
+		sort.Strings(list)
+
+		parts := make([]string, 0, len(list))
+		for _, alias := range list {
+			value, err := encodeSeedJSON(alias, "")
+			if err != nil {
+				return nil, err
+			}
+			parts = append(parts, string(value))
+		}
+
+		buf.WriteString(fmt.Sprintf("    { \"number\": %d, \"aliases\": [%s] }", number, strings.Join(parts, ", ")))
+		if i < len(numbers)-1 {
+			buf.WriteString(",")
+		}
+		buf.WriteString("\r\n")
+	}
+
+	buf.WriteString("  ]\r\n}")
+	return buf.Bytes(), nil
+}
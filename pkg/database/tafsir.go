@@ -0,0 +1,295 @@
+package database
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path"
+	"sort"
+	"strings"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	"khalif-alquran/internal/domain"
+
+)
+
+// Satu file per edisi tafsir (tafsir/<code>.json), dipakai baik oleh seeding maupun `import tafsir`.
+// Sumber yang hanya menyediakan tafsir per ayat boleh memakai "ayah" sebagai pengganti from/to;
+// ayat berurutan dengan teks yang sama (tafsir kelompok ayat yang diulang) digabung menjadi satu rentang.
+const seedTafsirPattern = "tafsir/*.json"
+
+type TafsirFile struct {
+	domain.TafsirEdition
+	Entries []TafsirFileEntry `json:"entries"`
+}
+
+type TafsirFileEntry struct {
+	Surah    int    `json:"surah"`
+	Ayah     int    `json:"ayah,omitempty"`
+	FromAyah int    `json:"from_ayah,omitempty"`
+	ToAyah   int    `json:"to_ayah,omitempty"`
+	Text     string `json:"text"`
+}
+
+type TafsirImportReport struct {
+	Edition   string `json:"edition"`
+	Entries   int    `json:"entries"` // setelah ayat berurutan bertekst sama digabung
+	Inserted  int    `json:"inserted"`
+	Updated   int    `json:"updated"`
+	Unchanged int    `json:"unchanged"`
+	Deleted   int    `json:"deleted"`
+}
+
+// ParseTafsirFile membaca satu file edisi tafsir dari disk
+func ParseTafsirFile(path string) (*TafsirFile, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	return ParseTafsir(f)
+}
+
+func ParseTafsir(r io.Reader) (*TafsirFile, error) {
+	var file TafsirFile
+	if err := json.NewDecoder(r).Decode(&file); err != nil {
+		return nil, err
+	}
+
+	if file.Code == "" || file.Language == "" || file.Name == "" {
+		return nil, fmt.Errorf("edition needs code, language and name")
+	}
+	if file.Form != domain.TafsirFormShort && file.Form != domain.TafsirFormLong {
+		return nil, fmt.Errorf("form must be %q or %q, found %q", domain.TafsirFormShort, domain.TafsirFormLong, file.Form)
+	}
+
+	for i, e := range file.Entries {
+		if e.Ayah > 0 {
+			if e.FromAyah != 0 || e.ToAyah != 0 {
+				return nil, fmt.Errorf("entry %d: use either ayah or from_ayah/to_ayah", i+1)
+			}
+			file.Entries[i].FromAyah, file.Entries[i].ToAyah, file.Entries[i].Ayah = e.Ayah, e.Ayah, 0
+		}
+		if file.Entries[i].ToAyah == 0 {
+			file.Entries[i].ToAyah = file.Entries[i].FromAyah
+		}
+		file.Entries[i].Text = strings.TrimSpace(e.Text)
+	}
+
+	return &file, nil
+}
+
+// tafsirEntries memvalidasi entri terhadap jumlah ayat per surah lalu menggabungkan
+// ayat berurutan dengan teks sama. Rentang dalam satu surah tidak boleh tumpang tindih.
+func tafsirEntries(file *TafsirFile, counts map[int]int) ([]domain.TafsirEntry, error) {
+	items := make([]TafsirFileEntry, len(file.Entries))
+	copy(items, file.Entries)
+	sort.SliceStable(items, func(i, j int) bool {
+		if items[i].Surah != items[j].Surah {
+			return items[i].Surah < items[j].Surah
+		}
+		return items[i].FromAyah < items[j].FromAyah
+	})
+
+	var entries []domain.TafsirEntry
+	for _, item := range items {
+		count, ok := counts[item.Surah]
+		if !ok {
+			return nil, fmt.Errorf("%d:%d: surah is not in the catalog", item.Surah, item.FromAyah)
+		}
+		if item.FromAyah < 1 || item.ToAyah < item.FromAyah || item.ToAyah > count {
+			return nil, fmt.Errorf("%d:%d-%d: invalid range, surah has %d ayahs", item.Surah, item.FromAyah, item.ToAyah, count)
+		}
+		if item.Text == "" {
+			return nil, fmt.Errorf("%d:%d-%d: empty text", item.Surah, item.FromAyah, item.ToAyah)
+		}
+
+		if n := len(entries); n > 0 && entries[n-1].SurahID == uint(item.Surah) {
+			prev := &entries[n-1]
+			if item.FromAyah <= prev.ToAyah {
+				return nil, fmt.Errorf("%d:%d-%d overlaps %d:%d-%d", item.Surah, item.FromAyah, item.ToAyah, prev.SurahID, prev.FromAyah, prev.ToAyah)
+			}
+			if item.FromAyah == prev.ToAyah+1 && item.Text == prev.Text {
+				prev.ToAyah = item.ToAyah
+				continue
+			}
+		}
+
+		entries = append(entries, domain.TafsirEntry{
+			Edition:  file.Code,
+			SurahID:  uint(item.Surah),
+			FromAyah: item.FromAyah,
+			ToAyah:   item.ToAyah,
+			Text:     item.Text,
+		})
+	}
+	return entries, nil
+}
+
+// loadSeedTafsir membaca semua file di tafsir/. Folder ini opsional.
+func loadSeedTafsir(source fs.FS, counts map[int]int) ([]*TafsirFile, error) {
+	files, err := fs.Glob(source, seedTafsirPattern)
+	if err != nil {
+		return nil, err
+	}
+
+	var editions []*TafsirFile
+	for _, filename := range files {
+		f, err := source.Open(filename)
+		if err != nil {
+			return nil, fmt.Errorf("read %s: %w", filename, err)
+		}
+		file, err := ParseTafsir(f)
+		f.Close()
+		if err != nil {
+			return nil, fmt.Errorf("parse %s: %w", filename, err)
+		}
+
+		if file.Code != strings.TrimSuffix(path.Base(filename), ".json") {
+			return nil, fmt.Errorf("%s: code %q must match the file name", filename, file.Code)
+		}
+		if _, err := tafsirEntries(file, counts); err != nil {
+			return nil, fmt.Errorf("%s: %w", filename, err)
+		}
+		editions = append(editions, file)
+	}
+	return editions, nil
+}
+
+// ImportTafsir menyimpan satu edisi tafsir dalam satu transaksi. Isi edisi diganti seluruhnya:
+// entri yang tidak ada di file dihapus, sehingga file selalu menjadi sumber kebenaran.
+func ImportTafsir(db *gorm.DB, file *TafsirFile) (*TafsirImportReport, error) {
+	var report *TafsirImportReport
+
+	err := db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Exec("SELECT pg_advisory_xact_lock(?)", seedLockKey).Error; err != nil {
+			return err
+		}
+
+		var surahs []domain.Surah
+		if err := tx.Select("number, total_ayahs").Find(&surahs).Error; err != nil {
+			return err
+		}
+		counts := make(map[int]int, len(surahs))
+		for _, s := range surahs {
+			counts[s.Number] = s.TotalAyahs
+		}
+
+		var err error
+		report, err = syncTafsirEdition(tx, file, counts, true)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return report, nil
+}
+
+// syncTafsir menyamakan setiap edisi yang punya file seed. Edisi lain di database
+// (hasil `import tafsir`) dibiarkan. Mengembalikan jumlah baris yang berubah.
+func syncTafsir(tx *gorm.DB, editions []*TafsirFile, counts map[int]int, overwrite bool) (int, error) {
+	changed := 0
+	for _, file := range editions {
+		report, err := syncTafsirEdition(tx, file, counts, overwrite)
+		if err != nil {
+			return 0, err
+		}
+		changed += report.Inserted + report.Updated + report.Deleted
+	}
+	return changed, nil
+}
+
+// syncTafsirEdition menyamakan satu edisi tafsir dengan file. Tanpa overwrite hanya entri yang
+// belum ada yang ditambah; katalog edisi dan entri yang sudah ada tidak diubah atau dihapus.
+func syncTafsirEdition(tx *gorm.DB, file *TafsirFile, counts map[int]int, overwrite bool) (*TafsirImportReport, error) {
+	entries, err := tafsirEntries(file, counts)
+	if err != nil {
+		return nil, err
+	}
+
+	report := &TafsirImportReport{Edition: file.Code, Entries: len(entries)}
+
+	var edition domain.TafsirEdition
+	err = tx.Where("code = ?", file.Code).Limit(1).Find(&edition).Error
+	if err != nil {
+		return nil, err
+	}
+	if edition != file.TafsirEdition && (overwrite || edition.Code == "") {
+		if err := tx.Clauses(clause.OnConflict{UpdateAll: true}).Create(&file.TafsirEdition).Error; err != nil {
+			return nil, fmt.Errorf("upsert tafsir edition %s: %w", file.Code, err)
+		}
+	}
+
+	var current []domain.TafsirEntry
+	if err := tx.Where("edition = ?", file.Code).Find(&current).Error; err != nil {
+		return nil, err
+	}
+	existing := make(map[[2]int]domain.TafsirEntry, len(current))
+	for _, e := range current {
+		existing[[2]int{int(e.SurahID), e.FromAyah}] = e
+	}
+
+	var upserts []domain.TafsirEntry
+	for _, e := range entries {
+		key := [2]int{int(e.SurahID), e.FromAyah}
+		old, ok := existing[key]
+		delete(existing, key)
+		switch {
+		case !ok:
+			report.Inserted++
+		case !overwrite || old == e:
+			report.Unchanged++
+			continue
+		default:
+			report.Updated++
+		}
+		upserts = append(upserts, e)
+	}
+
+	// Hapus dulu agar rentang baru yang menggeser awal entri lama tidak tumpang tindih
+	if overwrite {
+		for key := range existing {
+			err := tx.Where("edition = ? AND surah_id = ? AND from_ayah = ?", file.Code, key[0], key[1]).
+				Delete(&domain.TafsirEntry{}).Error
+			if err != nil {
+				return nil, fmt.Errorf("delete tafsir %s %d:%d: %w", file.Code, key[0], key[1], err)
+			}
+			report.Deleted++
+		}
+	}
+
+	if len(upserts) > 0 {
+		if err := tx.Clauses(clause.OnConflict{UpdateAll: true}).CreateInBatches(upserts, 200).Error; err != nil {
+			return nil, fmt.Errorf("upsert tafsir %s: %w", file.Code, err)
+		}
+	}
+
+	return report, nil
+}
+
+// encodeSeedTafsir menulis ulang tafsir/<code>.json dari isi database (dipakai dataset export)
+func encodeSeedTafsir(edition domain.TafsirEdition, entries []domain.TafsirEntry) ([]byte, error) {
+	sort.Slice(entries, func(i, j int) bool {
+		if entries[i].SurahID != entries[j].SurahID {
+			return entries[i].SurahID < entries[j].SurahID
+		}
+		return entries[i].FromAyah < entries[j].FromAyah
+	})
+
+	file := TafsirFile{TafsirEdition: edition, Entries: make([]TafsirFileEntry, 0, len(entries))}
+	for _, e := range entries {
+		file.Entries = append(file.Entries, TafsirFileEntry{
+			Surah:    int(e.SurahID),
+			FromAyah: e.FromAyah,
+			ToAyah:   e.ToAyah,
+			Text:     e.Text,
+		})
+	}
+
+	return encodeSeedJSON(file, "  ")
+}
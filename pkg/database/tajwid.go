@@ -0,0 +1,319 @@
+package database
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"sort"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	"khalif-alquran/internal/domain"
+
+)
+
+// Katalog hukum tajwid. Kode di katalog harus sama dengan label tajwid_info di data/*.json setelah
+// dinormalisasi oleh tajwidRuleCode, misal "Iqlab (Tanda Mim Kecil)" menjadi "iqlab". File ini opsional.
+const seedTajwidRuleFile = "tajwid_rules.json"
+
+type seedTajwidCatalog struct {
+	Rules []domain.TajwidRuleDefinition `json:"rules"`
+}
+
+var tajwidCategories = map[string]bool{
+	domain.TajwidNunSakinah: true,
+	domain.TajwidMimSakinah: true,
+	domain.TajwidGhunnah:    true,
+	domain.TajwidQalqalah:   true,
+	domain.TajwidMad:        true,
+	domain.TajwidLam:        true,
+	domain.TajwidWaqf:       true,
+}
+
+// loadSeedTajwidRules membaca katalog hukum tajwid. Hasilnya nil jika tajwid_rules.json tidak ada.
+func loadSeedTajwidRules(source fs.FS) ([]domain.TajwidRuleDefinition, error) {
+	data, err := fs.ReadFile(source, seedTajwidRuleFile)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("read %s: %w", seedTajwidRuleFile, err)
+	}
+
+	var catalog seedTajwidCatalog
+	if err := json.Unmarshal(data, &catalog); err != nil {
+		return nil, fmt.Errorf("parse %s: %w", seedTajwidRuleFile, err)
+	}
+
+	codes := make(map[string]bool, len(catalog.Rules))
+	for _, r := range catalog.Rules {
+		if r.Code == "" || r.NameID == "" || r.NameEN == "" {
+			return nil, fmt.Errorf("%s: every rule needs code, name_id and name_en", seedTajwidRuleFile)
+		}
+		if tajwidRuleCode(r.Code) != r.Code {
+			return nil, fmt.Errorf("%s: code %q must be lowercase letters, digits and underscores", seedTajwidRuleFile, r.Code)
+		}
+		if codes[r.Code] {
+			return nil, fmt.Errorf("%s: rule %q is listed more than once", seedTajwidRuleFile, r.Code)
+		}
+		codes[r.Code] = true
+
+		if !tajwidCategories[r.Category] {
+			return nil, fmt.Errorf("%s: rule %q has unknown category %q", seedTajwidRuleFile, r.Code, r.Category)
+		}
+		if !isHexColor(r.Color) {
+			return nil, fmt.Errorf("%s: rule %q color %q is not a #RRGGBB hex color", seedTajwidRuleFile, r.Code, r.Color)
+		}
+	}
+
+	return catalog.Rules, nil
+}
+
+func isHexColor(s string) bool {
+	if len(s) != 7 || s[0] != '#' {
+		return false
+	}
+	for _, r := range s[1:] {
+		if !strings.ContainsRune("0123456789abcdefABCDEF", r) {
+			return false
+		}
+	}
+	return true
+}
+
+// tajwidRuleCode mengubah label hukum di tajwid_info menjadi kode katalog: keterangan dalam kurung
+// dibuang, apostrof dihapus, dan karakter selain huruf/angka menjadi garis bawah.
+// Contoh: "Mad Thabi'i" menjadi "mad_thabii", "Iqlab (Tanda Mim Kecil)" menjadi "iqlab".
+func tajwidRuleCode(label string) string {
+	if i := strings.Index(label, "("); i >= 0 {
+		label = label[:i]
+	}
+
+	var b strings.Builder
+	separate := false
+	for _, r := range strings.ToLower(strings.TrimSpace(label)) {
+		switch {
+		case r == '\'' || r == '’':
+			continue
+		case unicode.IsLetter(r) || unicode.IsDigit(r):
+			if separate && b.Len() > 0 {
+				b.WriteByte('_')
+			}
+			separate = false
+			b.WriteRune(r)
+		default:
+			separate = true
+		}
+	}
+	return b.String()
+}
+
+// tajwidAnnotations menghitung rentang karakter setiap hukum di tajwid_info pada teks ayat.
+// Entri dengan hukum dan segmen yang sama dicari berurutan (entri ke-n menandai kemunculan ke-n),
+// dan segmen dengan "..." menghasilkan satu anotasi per potongan. Hukum yang tidak ada di rules
+// serta segmen yang tidak ditemukan dilewati.
+func tajwidAnnotations(script string, surah uint, ayah int, text string, list domain.TajwidList, rules map[string]bool) []domain.TajwidAnnotation {
+	type key struct {
+		start int
+		rule  string
+	}
+
+	var result []domain.TajwidAnnotation
+	index := make(map[key]int)
+	next := make(map[string]int) // posisi byte awal pencarian berikutnya per hukum dan segmen
+
+	for _, item := range list {
+		code := tajwidRuleCode(item.Rule)
+		if !rules[code] {
+			continue
+		}
+		segment := strings.TrimSpace(item.Segment)
+		searchKey := code + "\x00" + segment
+
+		var spans [][2]int
+		pos := next[searchKey]
+		for _, part := range strings.Split(segment, "...") {
+			part = strings.TrimSpace(part)
+			if part == "" {
+				continue
+			}
+			idx := strings.Index(text[pos:], part)
+			if idx < 0 {
+				spans = nil
+				break
+			}
+			spans = append(spans, [2]int{pos + idx, pos + idx + len(part)})
+			pos += idx + len(part)
+		}
+		if len(spans) == 0 {
+			continue
+		}
+		next[searchKey] = spans[0][1]
+
+		for _, s := range spans {
+			start := utf8.RuneCountInString(text[:s[0]])
+			end := start + utf8.RuneCountInString(text[s[0]:s[1]])
+
+			k := key{start, code}
+			if i, ok := index[k]; ok {
+				if end > result[i].End {
+					result[i].End = end
+				}
+				continue
+			}
+			index[k] = len(result)
+			result = append(result, domain.TajwidAnnotation{
+				Script:     script,
+				SurahID:    surah,
+				AyahNumber: ayah,
+				Start:      start,
+				Rule:       code,
+				End:        end,
+			})
+		}
+	}
+	return result
+}
+
+// syncTajwidRules meng-upsert katalog hukum tajwid. Hukum di database yang tidak ada di file dibiarkan,
+// dan tanpa overwrite hukum yang sudah ada juga tidak diubah.
+func syncTajwidRules(tx *gorm.DB, rules []domain.TajwidRuleDefinition, overwrite bool) (int, error) {
+	var current []domain.TajwidRuleDefinition
+	if err := tx.Find(&current).Error; err != nil {
+		return 0, err
+	}
+	existing := make(map[string]domain.TajwidRuleDefinition, len(current))
+	for _, r := range current {
+		existing[r.Code] = r
+	}
+
+	changed := 0
+	for _, r := range rules {
+		if old, ok := existing[r.Code]; ok && (!overwrite || old == r) {
+			continue
+		}
+		if err := tx.Clauses(clause.OnConflict{UpdateAll: true}).Create(&r).Error; err != nil {
+			return 0, fmt.Errorf("upsert tajwid rule %s: %w", r.Code, err)
+		}
+		changed++
+	}
+	return changed, nil
+}
+
+// rebuildTajwid menghitung ulang anotasi tajwid DefaultScript dari kolom tajwid_info dan text_arabic,
+// lalu menyamakannya dengan tabel tajwid_annotations. Dipanggil setiap kali teks atau tajwid_info
+// bisa berubah. Tidak melakukan apa-apa jika katalog hukum tajwid masih kosong.
+func rebuildTajwid(tx *gorm.DB) (int, error) {
+	var codes []string
+	if err := tx.Model(&domain.TajwidRuleDefinition{}).Pluck("code", &codes).Error; err != nil {
+		return 0, err
+	}
+	if len(codes) == 0 {
+		return 0, nil
+	}
+	rules := make(map[string]bool, len(codes))
+	for _, code := range codes {
+		rules[code] = true
+	}
+
+	var ayahs []domain.Ayah
+	err := tx.Select("surah_id", "number", "text_arabic", "tajwid_info").
+		Where("tajwid_info IS NOT NULL").
+		Find(&ayahs).Error
+	if err != nil {
+		return 0, err
+	}
+
+	type key struct {
+		surah, ayah, start int
+		rule               string
+	}
+
+	var current []domain.TajwidAnnotation
+	if err := tx.Where("script = ?", domain.DefaultScript).Find(&current).Error; err != nil {
+		return 0, err
+	}
+	existing := make(map[key]int, len(current))
+	for _, a := range current {
+		existing[key{int(a.SurahID), a.AyahNumber, a.Start, a.Rule}] = a.End
+	}
+
+	var upserts []domain.TajwidAnnotation
+	for _, ayah := range ayahs {
+		for _, a := range tajwidAnnotations(domain.DefaultScript, ayah.SurahID, ayah.Number, ayah.TextArabic, ayah.TajwidInfo, rules) {
+			k := key{int(a.SurahID), a.AyahNumber, a.Start, a.Rule}
+			end, ok := existing[k]
+			delete(existing, k)
+			if ok && end == a.End {
+				continue
+			}
+			upserts = append(upserts, a)
+		}
+	}
+
+	changed := 0
+	if len(upserts) > 0 {
+		if err := tx.Clauses(clause.OnConflict{UpdateAll: true}).CreateInBatches(upserts, 500).Error; err != nil {
+			return 0, fmt.Errorf("upsert tajwid annotations: %w", err)
+		}
+		changed += len(upserts)
+	}
+
+	for k := range existing {
+		err := tx.Where("script = ? AND surah_id = ? AND ayah_number = ? AND start_offset = ? AND rule = ?", domain.DefaultScript, k.surah, k.ayah, k.start, k.rule).
+			Delete(&domain.TajwidAnnotation{}).Error
+		if err != nil {
+			return 0, fmt.Errorf("delete tajwid annotation %d:%d@%d (%s): %w", k.surah, k.ayah, k.start, k.rule, err)
+		}
+		changed++
+	}
+
+	return changed, nil
+}
+
+// encodeSeedTajwidRules menulis tajwid_rules.json, satu hukum per baris agar mudah di-diff
+func encodeSeedTajwidRules(rules []domain.TajwidRuleDefinition) ([]byte, error) {
+	sort.Slice(rules, func(i, j int) bool { return rules[i].Code < rules[j].Code })
+
+	type field struct {
+		key   string
+		value string
+	}
+
+	var buf bytes.Buffer
+	buf.WriteString("{\r\n  \"rules\": [\r\n")
+
+	for i, r := range rules {
+		fields := []field{
+			{"code", r.Code},
+			{"name_id", r.NameID},
+			{"name_en", r.NameEN},
+			{"category", r.Category},
+			{"color", r.Color},
+		}
+
+		parts := make([]string, 0, len(fields))
+		for _, f := range fields {
+			value, err := encodeSeedJSON(f.value, "")
+			if err != nil {
+				return nil, err
+			}
+			parts = append(parts, fmt.Sprintf("%q: %s", f.key, value))
+		}
+
+		buf.WriteString("    { " + strings.Join(parts, ", ") + " }")
+		if i < len(rules)-1 {
+			buf.WriteString(",")
+		}
+		buf.WriteString("\r\n")
+	}
+
+	buf.WriteString("  ]\r\n}")
+	return buf.Bytes(), nil
+}
@@ -0,0 +1,277 @@
+package database
+
+import (
+	"bufio"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	"khalif-alquran/internal/domain"
+
+)
+
+// Format file ekspor Tanzil (https://tanzil.net/download)
+const (
+	TanzilFormatText = "text" // sura|aya|text per baris, komentar diawali '#'
+	TanzilFormatXML  = "xml"  // <quran><sura index=".."><aya index=".." text=".."/></sura></quran>
+)
+
+// Kolom tujuan import. Kolom lain (tafsir, tajwid, latin) tidak pernah disentuh importer.
+const (
+	ImportTargetArabic      = "arabic"
+	ImportTargetTranslation = "translation"
+)
+
+// TanzilVerse adalah satu baris hasil parsing file Tanzil
+type TanzilVerse struct {
+	Surah int
+	Ayah  int
+	Text  string
+}
+
+type ImportReport struct {
+	Target    string   `json:"target"`
+	Edition   string   `json:"edition,omitempty"` // edisi terjemahan non-default (ayah_translations) atau edisi rasm (ayah_scripts)
+	Verses    int      `json:"verses"`
+	Inserted  int      `json:"inserted"`
+	Updated   int      `json:"updated"`
+	Unchanged int      `json:"unchanged"`
+	Skipped   []string `json:"skipped,omitempty"` // "surah:ayah" yang belum ada di database (import terjemahan)
+}
+
+type tanzilXML struct {
+	Suras []struct {
+		Index int `xml:"index,attr"`
+		Ayas  []struct {
+			Index int    `xml:"index,attr"`
+			Text  string `xml:"text,attr"`
+		} `xml:"aya"`
+	} `xml:"sura"`
+}
+
+// DetectTanzilFormat menentukan format dari ekstensi file
+func DetectTanzilFormat(path string) string {
+	if strings.EqualFold(filepath.Ext(path), ".xml") {
+		return TanzilFormatXML
+	}
+	return TanzilFormatText
+}
+
+// ParseTanzilFile membaca file Tanzil (teks Arab maupun terjemahan) dari disk
+func ParseTanzilFile(path, format string) ([]TanzilVerse, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	if format == "" {
+		format = DetectTanzilFormat(path)
+	}
+
+	switch format {
+	case TanzilFormatText:
+		return ParseTanzilText(f)
+	case TanzilFormatXML:
+		return ParseTanzilXML(f)
+	default:
+		return nil, fmt.Errorf("unknown tanzil format %q", format)
+	}
+}
+
+func ParseTanzilText(r io.Reader) ([]TanzilVerse, error) {
+	var verses []TanzilVerse
+
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+
+	lineNo := 0
+	for scanner.Scan() {
+		lineNo++
+		line := strings.TrimSpace(strings.TrimPrefix(scanner.Text(), "\ufeff"))
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		parts := strings.SplitN(line, "|", 3)
+		if len(parts) != 3 {
+			return nil, fmt.Errorf("line %d: expected sura|aya|text", lineNo)
+		}
+
+		surah, err := strconv.Atoi(parts[0])
+		if err != nil {
+			return nil, fmt.Errorf("line %d: invalid sura %q", lineNo, parts[0])
+		}
+		ayah, err := strconv.Atoi(parts[1])
+		if err != nil {
+			return nil, fmt.Errorf("line %d: invalid aya %q", lineNo, parts[1])
+		}
+
+		verses = append(verses, TanzilVerse{Surah: surah, Ayah: ayah, Text: strings.TrimSpace(parts[2])})
+	}
+
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	return verses, checkTanzilVerses(verses)
+}
+
+func ParseTanzilXML(r io.Reader) ([]TanzilVerse, error) {
+	var doc tanzilXML
+	if err := xml.NewDecoder(r).Decode(&doc); err != nil {
+		return nil, err
+	}
+
+	var verses []TanzilVerse
+	for _, sura := range doc.Suras {
+		for _, aya := range sura.Ayas {
+			verses = append(verses, TanzilVerse{Surah: sura.Index, Ayah: aya.Index, Text: strings.TrimSpace(aya.Text)})
+		}
+	}
+	return verses, checkTanzilVerses(verses)
+}
+
+func checkTanzilVerses(verses []TanzilVerse) error {
+	seen := make(map[[2]int]bool, len(verses))
+	for _, v := range verses {
+		if v.Surah < 1 || v.Surah > totalSurahs {
+			return fmt.Errorf("%d:%d: surah number out of range", v.Surah, v.Ayah)
+		}
+		if v.Ayah < 1 {
+			return fmt.Errorf("%d:%d: ayah number out of range", v.Surah, v.Ayah)
+		}
+		if v.Text == "" {
+			return fmt.Errorf("%d:%d: empty text", v.Surah, v.Ayah)
+		}
+
+		key := [2]int{v.Surah, v.Ayah}
+		if seen[key] {
+			return fmt.Errorf("%d:%d: duplicate verse", v.Surah, v.Ayah)
+		}
+		seen[key] = true
+	}
+	return nil
+}
+
+// ImportTanzil menggabungkan hasil parsing ke tabel ayahs berdasarkan (surah, ayat) dalam satu transaksi.
+// Import teks Arab boleh membuat baris baru; import terjemahan hanya memperbarui ayat yang sudah ada.
+// Baris baru diberi number_in_quran dari katalog surah dan audio_url dari audioURLTemplate.
+func ImportTanzil(db *gorm.DB, verses []TanzilVerse, target, audioURLTemplate string) (*ImportReport, error) {
+	var column string
+	switch target {
+	case ImportTargetArabic:
+		column = "text_arabic"
+	case ImportTargetTranslation:
+		column = "translation"
+	default:
+		return nil, fmt.Errorf("unknown import target %q", target)
+	}
+
+	bySurah := make(map[int][]TanzilVerse)
+	for _, v := range verses {
+		bySurah[v.Surah] = append(bySurah[v.Surah], v)
+	}
+
+	surahNumbers := make([]int, 0, len(bySurah))
+	for number := range bySurah {
+		surahNumbers = append(surahNumbers, number)
+	}
+	sort.Ints(surahNumbers)
+
+	report := &ImportReport{Target: target, Verses: len(verses)}
+
+	err := db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Exec("SELECT pg_advisory_xact_lock(?)", seedLockKey).Error; err != nil {
+			return err
+		}
+
+		offsets, err := surahOffsets(tx)
+		if err != nil {
+			return err
+		}
+
+		for _, number := range surahNumbers {
+			var current []domain.Ayah
+			if err := tx.Where("surah_id = ?", number).Find(&current).Error; err != nil {
+				return err
+			}
+
+			byNumber := make(map[int]domain.Ayah, len(current))
+			for _, ayah := range current {
+				byNumber[ayah.Number] = ayah
+			}
+
+			var inserts []domain.Ayah
+			for _, v := range bySurah[number] {
+				old, ok := byNumber[v.Ayah]
+				if !ok {
+					if target != ImportTargetArabic {
+						report.Skipped = append(report.Skipped, fmt.Sprintf("%d:%d", v.Surah, v.Ayah))
+						continue
+					}
+					inserts = append(inserts, domain.Ayah{
+						SurahID:       uint(v.Surah),
+						Number:        v.Ayah,
+						NumberInQuran: offsets[v.Surah] + v.Ayah,
+						TextArabic:    v.Text,
+					})
+					continue
+				}
+
+				existing := old.TextArabic
+				if target == ImportTargetTranslation {
+					existing = old.Translation
+				}
+				if existing == v.Text {
+					report.Unchanged++
+					continue
+				}
+
+				if err := tx.Model(&domain.Ayah{}).Where("id = ?", old.ID).Update(column, v.Text).Error; err != nil {
+					return fmt.Errorf("update %d:%d: %w", v.Surah, v.Ayah, err)
+				}
+				report.Updated++
+			}
+
+			if len(inserts) > 0 {
+				if err := tx.Omit(clause.Associations).CreateInBatches(inserts, 100).Error; err != nil {
+					return fmt.Errorf("insert surah %d: %w", number, err)
+				}
+				report.Inserted += len(inserts)
+			}
+		}
+
+		if report.Inserted > 0 {
+			if _, err := fillAudioURLs(tx, audioURLTemplate); err != nil {
+				return fmt.Errorf("fill audio urls: %w", err)
+			}
+			if _, err := annotateAyahs(tx); err != nil {
+				return fmt.Errorf("annotate ayahs: %w", err)
+			}
+		}
+
+		// Teks pencarian dan offset anotasi tajwid diturunkan dari text_arabic, sehingga harus dihitung ulang jika teksnya berubah
+		if target == ImportTargetArabic && report.Inserted+report.Updated > 0 {
+			if _, err := normalizeAyahs(tx); err != nil {
+				return fmt.Errorf("normalize ayahs: %w", err)
+			}
+			if _, err := rebuildTajwid(tx); err != nil {
+				return fmt.Errorf("rebuild tajwid annotations: %w", err)
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return report, nil
+}
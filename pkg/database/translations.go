@@ -0,0 +1,381 @@
+package database
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"path"
+	"sort"
+	"strings"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	"khalif-alquran/internal/domain"
+
+)
+
+// translations.json berisi katalog edisi terjemahan. Teks edisi selain DefaultTranslation
+// disimpan di translations/<code>.txt dengan format teks Tanzil (sura|aya|text), sehingga
+// file unduhan tanzil.net bisa langsung diletakkan di sana.
+const (
+	seedTranslationFile    = "translations.json"
+	seedTranslationPattern = "translations/*.txt"
+)
+
+type seedTranslationCatalog struct {
+	Translations []domain.Translation `json:"translations"`
+}
+
+// seedTranslations adalah katalog beserta teks per edisi yang siap disimpan
+type seedTranslations struct {
+	Catalog []domain.Translation
+	Texts   map[string][]domain.AyahTranslation
+}
+
+// loadSeedTranslations membaca katalog dan teks terjemahan. Hasilnya nil jika
+// translations.json tidak ada, agar SEED_DIR lama tetap bisa dipakai.
+func loadSeedTranslations(source fs.FS, counts map[int]int) (*seedTranslations, error) {
+	data, err := fs.ReadFile(source, seedTranslationFile)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("read %s: %w", seedTranslationFile, err)
+	}
+
+	var catalog seedTranslationCatalog
+	if err := json.Unmarshal(data, &catalog); err != nil {
+		return nil, fmt.Errorf("parse %s: %w", seedTranslationFile, err)
+	}
+
+	editions := make(map[string]bool, len(catalog.Translations))
+	for _, t := range catalog.Translations {
+		if t.Code == "" || t.Language == "" || t.Name == "" {
+			return nil, fmt.Errorf("%s: every edition needs code, language and name", seedTranslationFile)
+		}
+		if editions[t.Code] {
+			return nil, fmt.Errorf("%s: edition %q is listed more than once", seedTranslationFile, t.Code)
+		}
+		editions[t.Code] = true
+	}
+	if !editions[domain.DefaultTranslation] {
+		return nil, fmt.Errorf("%s: default edition %q is missing", seedTranslationFile, domain.DefaultTranslation)
+	}
+
+	files, err := fs.Glob(source, seedTranslationPattern)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &seedTranslations{Catalog: catalog.Translations, Texts: make(map[string][]domain.AyahTranslation)}
+	for _, filename := range files {
+		edition := strings.TrimSuffix(path.Base(filename), ".txt")
+		if !editions[edition] {
+			return nil, fmt.Errorf("%s: edition %q is not listed in %s", filename, edition, seedTranslationFile)
+		}
+		if edition == domain.DefaultTranslation {
+			return nil, fmt.Errorf("%s: the default edition is stored in the per-surah files, not here", filename)
+		}
+
+		f, err := source.Open(filename)
+		if err != nil {
+			return nil, fmt.Errorf("read %s: %w", filename, err)
+		}
+		verses, err := ParseTanzilText(f)
+		f.Close()
+		if err != nil {
+			return nil, fmt.Errorf("parse %s: %w", filename, err)
+		}
+
+		rows := make([]domain.AyahTranslation, 0, len(verses))
+		for _, v := range verses {
+			if count, ok := counts[v.Surah]; !ok || v.Ayah > count {
+				return nil, fmt.Errorf("%s: %d:%d is not in the surah catalog", filename, v.Surah, v.Ayah)
+			}
+			rows = append(rows, domain.AyahTranslation{
+				Edition:    edition,
+				SurahID:    uint(v.Surah),
+				AyahNumber: v.Ayah,
+				Text:       v.Text,
+			})
+		}
+		result.Texts[edition] = rows
+	}
+
+	return result, nil
+}
+
+// syncTranslations meng-upsert katalog lalu menyamakan teks tiap edisi yang punya file.
+// Edisi di database yang tidak ada di katalog seed dibiarkan (bisa saja hasil import).
+// Ayat yang belum ada di tabel ayahs dilewati karena foreign key. Tanpa overwrite hanya
+// katalog dan teks yang belum ada yang ditambah.
+func syncTranslations(tx *gorm.DB, seed *seedTranslations, overwrite bool) (int, error) {
+	changed := 0
+
+	var current []domain.Translation
+	if err := tx.Find(&current).Error; err != nil {
+		return 0, err
+	}
+	existing := make(map[string]domain.Translation, len(current))
+	for _, t := range current {
+		existing[t.Code] = t
+	}
+
+	for _, t := range seed.Catalog {
+		if old, ok := existing[t.Code]; ok && (!overwrite || old == t) {
+			continue
+		}
+		if err := tx.Clauses(clause.OnConflict{UpdateAll: true}).Create(&t).Error; err != nil {
+			return 0, fmt.Errorf("upsert translation %s: %w", t.Code, err)
+		}
+		changed++
+	}
+
+	ayahs, err := ayahKeys(tx)
+	if err != nil {
+		return 0, err
+	}
+
+	editions := make([]string, 0, len(seed.Texts))
+	for edition := range seed.Texts {
+		editions = append(editions, edition)
+	}
+	sort.Strings(editions)
+
+	for _, edition := range editions {
+		var present map[[2]int]bool
+		if !overwrite {
+			if present, err = editionKeys(tx, &domain.AyahTranslation{}, "edition", edition); err != nil {
+				return 0, err
+			}
+		}
+
+		var rows []domain.AyahTranslation
+		for _, row := range seed.Texts[edition] {
+			key := [2]int{int(row.SurahID), row.AyahNumber}
+			if ayahs[key] && !present[key] {
+				rows = append(rows, row)
+			}
+		}
+
+		n, err := mergeAyahTranslations(tx, edition, rows, overwrite)
+		if err != nil {
+			return 0, err
+		}
+		changed += n
+	}
+
+	return changed, nil
+}
+
+// mergeAyahTranslations menulis teks satu edisi: baris yang berbeda di-upsert, dan jika prune
+// bernilai true, baris yang tidak ada di rows dihapus. Mengembalikan jumlah baris yang berubah.
+func mergeAyahTranslations(tx *gorm.DB, edition string, rows []domain.AyahTranslation, prune bool) (int, error) {
+	var current []domain.AyahTranslation
+	if err := tx.Where("edition = ?", edition).Find(&current).Error; err != nil {
+		return 0, err
+	}
+
+	existing := make(map[[2]int]string, len(current))
+	for _, row := range current {
+		existing[[2]int{int(row.SurahID), row.AyahNumber}] = row.Text
+	}
+
+	var upserts []domain.AyahTranslation
+	for _, row := range rows {
+		key := [2]int{int(row.SurahID), row.AyahNumber}
+		text, ok := existing[key]
+		delete(existing, key)
+		if ok && text == row.Text {
+			continue
+		}
+		upserts = append(upserts, row)
+	}
+
+	changed := 0
+	if len(upserts) > 0 {
+		if err := tx.Clauses(clause.OnConflict{UpdateAll: true}).CreateInBatches(upserts, 500).Error; err != nil {
+			return 0, fmt.Errorf("upsert %s: %w", edition, err)
+		}
+		changed += len(upserts)
+	}
+
+	if prune {
+		for key := range existing {
+			err := tx.Where("edition = ? AND surah_id = ? AND ayah_number = ?", edition, key[0], key[1]).
+				Delete(&domain.AyahTranslation{}).Error
+			if err != nil {
+				return 0, fmt.Errorf("delete %s %d:%d: %w", edition, key[0], key[1], err)
+			}
+			changed++
+		}
+	}
+
+	return changed, nil
+}
+
+// ayahKeys mengembalikan pasangan (surah, ayat) yang sudah ada di tabel ayahs
+func ayahKeys(tx *gorm.DB) (map[[2]int]bool, error) {
+	var rows []struct {
+		SurahID uint
+		Number  int
+	}
+	if err := tx.Model(&domain.Ayah{}).Select("surah_id, number").Scan(&rows).Error; err != nil {
+		return nil, err
+	}
+
+	keys := make(map[[2]int]bool, len(rows))
+	for _, row := range rows {
+		keys[[2]int{int(row.SurahID), row.Number}] = true
+	}
+	return keys, nil
+}
+
+// editionKeys mengembalikan pasangan (surah, ayat) yang sudah punya teks untuk satu edisi
+// di tabel per-ayat seperti ayah_translations atau ayah_scripts
+func editionKeys(tx *gorm.DB, model interface{}, column, edition string) (map[[2]int]bool, error) {
+	var rows []struct {
+		SurahID    uint
+		AyahNumber int
+	}
+	if err := tx.Model(model).Select("surah_id, ayah_number").Where(column+" = ?", edition).Scan(&rows).Error; err != nil {
+		return nil, err
+	}
+
+	keys := make(map[[2]int]bool, len(rows))
+	for _, row := range rows {
+		keys[[2]int{int(row.SurahID), row.AyahNumber}] = true
+	}
+	return keys, nil
+}
+
+// ImportTanzilTranslation menggabungkan file terjemahan Tanzil ke ayah_translations untuk satu edisi
+// non-default. Edisi harus sudah ada di katalog (translations.json). Ayat yang belum ada dilewati.
+func ImportTanzilTranslation(db *gorm.DB, verses []TanzilVerse, edition string) (*ImportReport, error) {
+	if edition == domain.DefaultTranslation {
+		return nil, fmt.Errorf("edition %s is stored in ayahs.translation, import it without --edition", edition)
+	}
+
+	report := &ImportReport{Target: ImportTargetTranslation, Edition: edition, Verses: len(verses)}
+
+	err := db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Exec("SELECT pg_advisory_xact_lock(?)", seedLockKey).Error; err != nil {
+			return err
+		}
+
+		var count int64
+		if err := tx.Model(&domain.Translation{}).Where("code = ?", edition).Count(&count).Error; err != nil {
+			return err
+		}
+		if count == 0 {
+			return fmt.Errorf("edition %q is not in the translations catalog, add it to %s and run seed first", edition, seedTranslationFile)
+		}
+
+		ayahs, err := ayahKeys(tx)
+		if err != nil {
+			return err
+		}
+
+		var current []domain.AyahTranslation
+		if err := tx.Where("edition = ?", edition).Find(&current).Error; err != nil {
+			return err
+		}
+		existing := make(map[[2]int]string, len(current))
+		for _, row := range current {
+			existing[[2]int{int(row.SurahID), row.AyahNumber}] = row.Text
+		}
+
+		var rows []domain.AyahTranslation
+		for _, v := range verses {
+			key := [2]int{v.Surah, v.Ayah}
+			if !ayahs[key] {
+				report.Skipped = append(report.Skipped, fmt.Sprintf("%d:%d", v.Surah, v.Ayah))
+				continue
+			}
+
+			text, ok := existing[key]
+			switch {
+			case !ok:
+				report.Inserted++
+			case text == v.Text:
+				report.Unchanged++
+				continue
+			default:
+				report.Updated++
+			}
+			rows = append(rows, domain.AyahTranslation{
+				Edition:    edition,
+				SurahID:    uint(v.Surah),
+				AyahNumber: v.Ayah,
+				Text:       v.Text,
+			})
+		}
+
+		_, err = mergeAyahTranslations(tx, edition, rows, false)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return report, nil
+}
+
+// encodeSeedTranslationCatalog menulis translations.json, satu edisi per baris
+func encodeSeedTranslationCatalog(catalog []domain.Translation) ([]byte, error) {
+	sort.Slice(catalog, func(i, j int) bool { return catalog[i].Code < catalog[j].Code })
+
+	var buf bytes.Buffer
+	buf.WriteString("{\r\n  \"translations\": [\r\n")
+
+	for i, t := range catalog {
+		fields := []struct {
+			key   string
+			value string
+		}{
+			{"code", t.Code},
+			{"language", t.Language},
+			{"name", t.Name},
+			{"translator", t.Translator},
+			{"source", t.Source},
+			{"license", t.License},
+		}
+
+		parts := make([]string, 0, len(fields))
+		for _, f := range fields {
+			value, err := encodeSeedJSON(f.value, "")
+			if err != nil {
+				return nil, err
+			}
+			parts = append(parts, fmt.Sprintf("%q: %s", f.key, value))
+		}
+
+		buf.WriteString("    { " + strings.Join(parts, ", ") + " }")
+		if i < len(catalog)-1 {
+			buf.WriteString(",")
+		}
+		buf.WriteString("\r\n")
+	}
+
+	buf.WriteString("  ]\r\n}")
+	return buf.Bytes(), nil
+}
+
+// encodeSeedTranslationText menulis teks satu edisi dalam format teks Tanzil
+func encodeSeedTranslationText(rows []domain.AyahTranslation) []byte {
+	sort.Slice(rows, func(i, j int) bool {
+		if rows[i].SurahID != rows[j].SurahID {
+			return rows[i].SurahID < rows[j].SurahID
+		}
+		return rows[i].AyahNumber < rows[j].AyahNumber
+	})
+
+	lines := make([]string, 0, len(rows))
+	for _, row := range rows {
+		lines = append(lines, fmt.Sprintf("%d|%d|%s", row.SurahID, row.AyahNumber, row.Text))
+	}
+	return []byte(strings.Join(lines, "\r\n"))
+}
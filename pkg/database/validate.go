@@ -0,0 +1,295 @@
+package database
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"sort"
+	"strings"
+	"unicode"
+
+	"khalif-alquran/internal/domain"
+)
+
+// Jumlah ayat Al-Quran menurut penomoran Kufi (riwayat Hafs)
+const TotalQuranAyahs = domain.TotalQuranAyahs
+
+const totalSurahs = 114
+
+// Kode aturan validasi, dipakai di laporan JSON agar mudah difilter oleh CI
+const (
+	RuleJSONParse        = "json_parse"
+	RuleSurahNumberRange = "surah_number_range"
+	RuleSurahDuplicate   = "surah_duplicate"
+	RuleSurahMissing     = "surah_missing"
+	RuleCatalogMismatch  = "catalog_mismatch"
+	RuleAyahCount        = "ayah_count_mismatch"
+	RuleAyahSequence     = "ayah_sequence"
+	RuleArabicEmpty      = "arabic_text_empty"
+	RuleArabicScript     = "arabic_text_script"
+	RuleTajwidSegment    = "tajwid_segment_missing"
+	RuleGlobalAyahTotal  = "global_ayah_total"
+	RuleDivision         = "division_invalid"
+	RuleMushaf           = "mushaf_invalid"
+	RuleTranslation      = "translation_invalid"
+	RuleTafsir           = "tafsir_invalid"
+	RuleWord             = "word_invalid"
+	RuleScript           = "script_invalid"
+	RuleRiwayah          = "riwayah_invalid"
+	RuleRiwayahCoverage  = "riwayah_incomplete"
+	RuleTajwidRule       = "tajwid_rule_unknown"
+	RuleSurahAlias       = "surah_alias_invalid"
+)
+
+type SeedViolation struct {
+	File    string `json:"file"`
+	Surah   int    `json:"surah,omitempty"`
+	Ayah    int    `json:"ayah,omitempty"`
+	Rule    string `json:"rule"`
+	Message string `json:"message"`
+}
+
+type SeedValidationReport struct {
+	Valid        bool            `json:"valid"`
+	FilesChecked int             `json:"files_checked"`
+	Surahs       int             `json:"surahs"`
+	CatalogAyahs int             `json:"catalog_ayahs"`
+	ContentAyahs int             `json:"content_ayahs"`
+	Violations   []SeedViolation `json:"violations"`
+}
+
+func (r *SeedValidationReport) add(file string, surah, ayah int, rule, format string, args ...interface{}) {
+	r.Violations = append(r.Violations, SeedViolation{
+		File:    file,
+		Surah:   surah,
+		Ayah:    ayah,
+		Rule:    rule,
+		Message: fmt.Sprintf(format, args...),
+	})
+}
+
+// ValidateSeed memeriksa seluruh file di corpus seed tanpa menyentuh database,
+// sehingga bisa dijalankan di CI sebelum dataset dipublikasikan
+func ValidateSeed(source fs.FS) (*SeedValidationReport, error) {
+	report := &SeedValidationReport{Violations: []SeedViolation{}}
+
+	catalogCounts := validateCatalog(source, report)
+
+	// Pembagian juz/hizb/dst hanya bisa diperiksa jika katalog lengkap
+	if len(catalogCounts) == totalSurahs {
+		layouts, err := loadSeedMushaf(source, catalogCounts)
+		if err != nil {
+			report.add(seedMushafPattern, 0, 0, RuleMushaf, "%v", err)
+		}
+		if _, _, err := loadSeedDivisions(source, catalogCounts, defaultMushafPages(layouts)); err != nil {
+			report.add(seedDivisionFile, 0, 0, RuleDivision, "%v", err)
+		}
+		if _, err := loadSeedTranslations(source, catalogCounts); err != nil {
+			report.add(seedTranslationFile, 0, 0, RuleTranslation, "%v", err)
+		}
+		if _, err := loadSeedTafsir(source, catalogCounts); err != nil {
+			report.add(seedTafsirPattern, 0, 0, RuleTafsir, "%v", err)
+		}
+		if _, err := loadSeedWords(source, catalogCounts); err != nil {
+			report.add(seedWordPattern, 0, 0, RuleWord, "%v", err)
+		}
+		if _, err := loadSeedScripts(source, catalogCounts); err != nil {
+			report.add(seedScriptPattern, 0, 0, RuleScript, "%v", err)
+		}
+		// File riwayat harus mencakup 114 surah dan jumlah ayatnya sama dengan total_ayahs
+		if _, err := loadSeedRiwayat(source, catalogCounts); errors.Is(err, errRiwayahIncomplete) {
+			report.add(seedRiwayahPattern, 0, 0, RuleRiwayahCoverage, "%v", err)
+		} else if err != nil {
+			report.add(seedRiwayahPattern, 0, 0, RuleRiwayah, "%v", err)
+		}
+		if _, err := loadSeedSurahAliases(source, catalogCounts); err != nil {
+			report.add(seedSurahAliasFile, 0, 0, RuleSurahAlias, "%v", err)
+		}
+	}
+
+	// Label tajwid_info hanya dicocokkan ke katalog jika tajwid_rules.json ada
+	var tajwidRules map[string]bool
+	rules, err := loadSeedTajwidRules(source)
+	if err != nil {
+		report.add(seedTajwidRuleFile, 0, 0, RuleTajwidRule, "%v", err)
+	} else if rules != nil {
+		tajwidRules = make(map[string]bool, len(rules))
+		for _, r := range rules {
+			tajwidRules[r.Code] = true
+		}
+	}
+
+	files, err := fs.Glob(source, seedDataPattern)
+	if err != nil {
+		return nil, err
+	}
+
+	seen := make(map[int]string)
+	for _, filename := range files {
+		report.FilesChecked++
+
+		data, err := fs.ReadFile(source, filename)
+		if err != nil {
+			return nil, err
+		}
+
+		var surah domain.Surah
+		if err := json.Unmarshal(data, &surah); err != nil {
+			report.add(filename, 0, 0, RuleJSONParse, "%v", err)
+			continue
+		}
+
+		if surah.Number < 1 || surah.Number > totalSurahs {
+			report.add(filename, surah.Number, 0, RuleSurahNumberRange, "surah number %d is outside 1..%d", surah.Number, totalSurahs)
+		}
+		if other, ok := seen[surah.Number]; ok {
+			report.add(filename, surah.Number, 0, RuleSurahDuplicate, "surah %d is also defined in %s", surah.Number, other)
+		}
+		seen[surah.Number] = filename
+
+		if catalogCount, ok := catalogCounts[surah.Number]; ok && catalogCount != surah.TotalAyahs {
+			report.add(filename, surah.Number, 0, RuleCatalogMismatch, "ayah_count is %d but %s says %d", surah.TotalAyahs, seedCatalogFile, catalogCount)
+		}
+
+		validateSurahContent(filename, surah, tajwidRules, report)
+		report.ContentAyahs += len(surah.Ayahs)
+	}
+
+	report.Valid = len(report.Violations) == 0
+	return report, nil
+}
+
+// validateCatalog memeriksa quran.json dan mengembalikan ayah_count per nomor surah
+func validateCatalog(source fs.FS, report *SeedValidationReport) map[int]int {
+	counts := make(map[int]int)
+	report.FilesChecked++
+
+	data, err := fs.ReadFile(source, seedCatalogFile)
+	if err != nil {
+		report.add(seedCatalogFile, 0, 0, RuleJSONParse, "%v", err)
+		return counts
+	}
+
+	var catalog seedCatalog
+	if err := json.Unmarshal(data, &catalog); err != nil {
+		report.add(seedCatalogFile, 0, 0, RuleJSONParse, "%v", err)
+		return counts
+	}
+
+	for _, surah := range catalog.Surahs {
+		if surah.Number < 1 || surah.Number > totalSurahs {
+			report.add(seedCatalogFile, surah.Number, 0, RuleSurahNumberRange, "surah number %d is outside 1..%d", surah.Number, totalSurahs)
+			continue
+		}
+		if _, ok := counts[surah.Number]; ok {
+			report.add(seedCatalogFile, surah.Number, 0, RuleSurahDuplicate, "surah %d is listed more than once", surah.Number)
+			continue
+		}
+		counts[surah.Number] = surah.TotalAyahs
+		report.CatalogAyahs += surah.TotalAyahs
+	}
+	report.Surahs = len(counts)
+
+	var missing []string
+	for number := 1; number <= totalSurahs; number++ {
+		if _, ok := counts[number]; !ok {
+			missing = append(missing, fmt.Sprint(number))
+		}
+	}
+	if len(missing) > 0 {
+		report.add(seedCatalogFile, 0, 0, RuleSurahMissing, "surahs not listed: %s", strings.Join(missing, ", "))
+	}
+
+	if report.CatalogAyahs != TotalQuranAyahs {
+		report.add(seedCatalogFile, 0, 0, RuleGlobalAyahTotal, "ayah_count values add up to %d, expected %d", report.CatalogAyahs, TotalQuranAyahs)
+	}
+
+	return counts
+}
+
+func validateSurahContent(filename string, surah domain.Surah, tajwidRules map[string]bool, report *SeedValidationReport) {
+	if len(surah.Ayahs) != surah.TotalAyahs {
+		report.add(filename, surah.Number, 0, RuleAyahCount, "file has %d ayahs but ayah_count is %d", len(surah.Ayahs), surah.TotalAyahs)
+	}
+
+	ayahs := make([]domain.Ayah, len(surah.Ayahs))
+	copy(ayahs, surah.Ayahs)
+	sort.SliceStable(ayahs, func(i, j int) bool {
+		return ayahs[i].Number < ayahs[j].Number
+	})
+
+	for i, ayah := range ayahs {
+		if ayah.Number != i+1 {
+			report.add(filename, surah.Number, ayah.Number, RuleAyahSequence, "expected ayah %d at position %d, found %d", i+1, i+1, ayah.Number)
+		}
+
+		text := strings.TrimSpace(ayah.TextArabic)
+		if text == "" {
+			report.add(filename, surah.Number, ayah.Number, RuleArabicEmpty, "text_arabic is empty")
+			continue
+		}
+
+		if r, ok := firstNonArabicRune(text); ok {
+			report.add(filename, surah.Number, ayah.Number, RuleArabicScript, "text_arabic contains non-Arabic character %q (U+%04X)", r, r)
+		}
+
+		for _, tajwid := range ayah.TajwidInfo {
+			if !segmentOccurs(ayah.TextArabic, tajwid.Segment) {
+				report.add(filename, surah.Number, ayah.Number, RuleTajwidSegment, "tajwid segment %q (%s) does not occur in the ayah text", tajwid.Segment, tajwid.Rule)
+			}
+			if code := tajwidRuleCode(tajwid.Rule); tajwidRules != nil && !tajwidRules[code] {
+				report.add(filename, surah.Number, ayah.Number, RuleTajwidRule, "tajwid rule %q (code %q) is not in %s", tajwid.Rule, code, seedTajwidRuleFile)
+			}
+		}
+	}
+}
+
+// segmentOccurs memeriksa apakah segmen tajwid ada di teks ayat.
+// Segmen dengan "..." (misal waqaf mu'anaqah) berarti potongan-potongannya muncul berurutan.
+func segmentOccurs(text, segment string) bool {
+	segment = strings.TrimSpace(segment)
+	if segment == "" {
+		return false
+	}
+
+	rest := text
+	for _, part := range strings.Split(segment, "...") {
+		part = strings.TrimSpace(part)
+		if part == "" {
+			continue
+		}
+		idx := strings.Index(rest, part)
+		if idx < 0 {
+			return false
+		}
+		rest = rest[idx+len(part):]
+	}
+	return true
+}
+
+func firstNonArabicRune(text string) (rune, bool) {
+	for _, r := range text {
+		if unicode.IsSpace(r) || isArabicRune(r) {
+			continue
+		}
+		return r, true
+	}
+	return 0, false
+}
+
+// isArabicRune mencakup blok Unicode Arabic termasuk harakat dan tanda waqaf Utsmani,
+// yang secara Unicode bertipe "Inherited" sehingga tidak lolos unicode.Arabic
+func isArabicRune(r rune) bool {
+	switch {
+	case r >= 0x0600 && r <= 0x06FF: // Arabic
+	case r >= 0x0750 && r <= 0x077F: // Arabic Supplement
+	case r >= 0x08A0 && r <= 0x08FF: // Arabic Extended-A
+	case r >= 0xFB50 && r <= 0xFDFF: // Arabic Presentation Forms-A
+	case r >= 0xFE70 && r <= 0xFEFC: // Arabic Presentation Forms-B (tanpa BOM U+FEFF)
+	case r == 0x200C || r == 0x200D: // ZWNJ / ZWJ untuk kontrol ligatur
+	default:
+		return false
+	}
+	return true
+}
@@ -0,0 +1,204 @@
+package database
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io/fs"
+	"path"
+	"sort"
+	"strings"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	"khalif-alquran/internal/domain"
+
+)
+
+// Kata per kata disimpan per surah di words/<nomor surah 3 digit>.json, satu kata per baris.
+// Folder ini opsional; surah tanpa file tidak memiliki data kata per kata.
+const seedWordPattern = "words/*.json"
+
+type seedWordFile struct {
+	Surah int        `json:"surah"`
+	Words []seedWord `json:"words"`
+}
+
+type seedWord struct {
+	Ayah            int    `json:"ayah"`
+	Position        int    `json:"position"`
+	TextUthmani     string `json:"text_uthmani"`
+	Transliteration string `json:"transliteration"`
+	TranslationID   string `json:"translation_id"`
+	TranslationEN   string `json:"translation_en"`
+}
+
+// loadSeedWords membaca semua file kata per kata, dikelompokkan per nomor surah.
+// Posisi kata dalam satu ayat harus berurutan mulai dari 1.
+func loadSeedWords(source fs.FS, counts map[int]int) (map[int][]domain.Word, error) {
+	files, err := fs.Glob(source, seedWordPattern)
+	if err != nil {
+		return nil, err
+	}
+	if len(files) == 0 {
+		return nil, nil
+	}
+
+	result := make(map[int][]domain.Word, len(files))
+	for _, filename := range files {
+		data, err := fs.ReadFile(source, filename)
+		if err != nil {
+			return nil, fmt.Errorf("read %s: %w", filename, err)
+		}
+
+		var file seedWordFile
+		if err := json.Unmarshal(data, &file); err != nil {
+			return nil, fmt.Errorf("parse %s: %w", filename, err)
+		}
+
+		count, ok := counts[file.Surah]
+		if !ok {
+			return nil, fmt.Errorf("%s: surah %d is not in the catalog", filename, file.Surah)
+		}
+		if want := fmt.Sprintf("%03d.json", file.Surah); path.Base(filename) != want {
+			return nil, fmt.Errorf("%s: words of surah %d belong in %s", filename, file.Surah, want)
+		}
+
+		next := make(map[int]int)
+		words := make([]domain.Word, 0, len(file.Words))
+		for _, w := range file.Words {
+			if w.Ayah < 1 || w.Ayah > count {
+				return nil, fmt.Errorf("%s: %d:%d is not in the surah catalog", filename, file.Surah, w.Ayah)
+			}
+			if w.Position != next[w.Ayah]+1 {
+				return nil, fmt.Errorf("%s: %d:%d word %d out of sequence, expected %d", filename, file.Surah, w.Ayah, w.Position, next[w.Ayah]+1)
+			}
+			next[w.Ayah] = w.Position
+
+			if strings.TrimSpace(w.TextUthmani) == "" {
+				return nil, fmt.Errorf("%s: %d:%d word %d has no text", filename, file.Surah, w.Ayah, w.Position)
+			}
+
+			words = append(words, domain.Word{
+				SurahID:         uint(file.Surah),
+				AyahNumber:      w.Ayah,
+				Position:        w.Position,
+				TextUthmani:     w.TextUthmani,
+				Transliteration: w.Transliteration,
+				TranslationID:   w.TranslationID,
+				TranslationEN:   w.TranslationEN,
+			})
+		}
+		result[file.Surah] = words
+	}
+
+	return result, nil
+}
+
+// syncWords menyamakan kata per kata setiap surah yang punya file seed. Kata untuk ayat
+// yang belum ada di tabel ayahs dilewati karena foreign key. Tanpa overwrite hanya kata yang belum ada
+// yang ditambah. Mengembalikan jumlah baris yang berubah.
+func syncWords(tx *gorm.DB, seed map[int][]domain.Word, overwrite bool) (int, error) {
+	ayahs, err := ayahKeys(tx)
+	if err != nil {
+		return 0, err
+	}
+
+	surahs := make([]int, 0, len(seed))
+	for number := range seed {
+		surahs = append(surahs, number)
+	}
+	sort.Ints(surahs)
+
+	changed := 0
+	for _, number := range surahs {
+		var current []domain.Word
+		if err := tx.Where("surah_id = ?", number).Find(&current).Error; err != nil {
+			return 0, err
+		}
+		existing := make(map[[2]int]domain.Word, len(current))
+		for _, w := range current {
+			existing[[2]int{w.AyahNumber, w.Position}] = w
+		}
+
+		var upserts []domain.Word
+		for _, w := range seed[number] {
+			if !ayahs[[2]int{number, w.AyahNumber}] {
+				continue
+			}
+			key := [2]int{w.AyahNumber, w.Position}
+			old, ok := existing[key]
+			delete(existing, key)
+			if ok && (!overwrite || old == w) {
+				continue
+			}
+			upserts = append(upserts, w)
+		}
+
+		if len(upserts) > 0 {
+			if err := tx.Clauses(clause.OnConflict{UpdateAll: true}).CreateInBatches(upserts, 500).Error; err != nil {
+				return 0, fmt.Errorf("upsert words of surah %d: %w", number, err)
+			}
+			changed += len(upserts)
+		}
+
+		if overwrite {
+			for key := range existing {
+				err := tx.Where("surah_id = ? AND ayah_number = ? AND position = ?", number, key[0], key[1]).
+					Delete(&domain.Word{}).Error
+				if err != nil {
+					return 0, fmt.Errorf("delete word %d:%d/%d: %w", number, key[0], key[1], err)
+				}
+				changed++
+			}
+		}
+	}
+
+	return changed, nil
+}
+
+// encodeSeedWords menulis words/<surah>.json, satu kata per baris agar mudah di-diff
+func encodeSeedWords(surah int, words []domain.Word) ([]byte, error) {
+	sort.Slice(words, func(i, j int) bool {
+		if words[i].AyahNumber != words[j].AyahNumber {
+			return words[i].AyahNumber < words[j].AyahNumber
+		}
+		return words[i].Position < words[j].Position
+	})
+
+	var buf bytes.Buffer
+	fmt.Fprintf(&buf, "{\r\n  \"surah\": %d,\r\n  \"words\": [\r\n", surah)
+
+	for i, w := range words {
+		fields := []struct {
+			key   string
+			value interface{}
+		}{
+			{"ayah", w.AyahNumber},
+			{"position", w.Position},
+			{"text_uthmani", w.TextUthmani},
+			{"transliteration", w.Transliteration},
+			{"translation_id", w.TranslationID},
+			{"translation_en", w.TranslationEN},
+		}
+
+		parts := make([]string, 0, len(fields))
+		for _, f := range fields {
+			value, err := encodeSeedJSON(f.value, "")
+			if err != nil {
+				return nil, err
+			}
+			parts = append(parts, fmt.Sprintf("%q: %s", f.key, value))
+		}
+
+		buf.WriteString("    { " + strings.Join(parts, ", ") + " }")
+		if i < len(words)-1 {
+			buf.WriteString(",")
+		}
+		buf.WriteString("\r\n")
+	}
+
+	buf.WriteString("  ]\r\n}")
+	return buf.Bytes(), nil
+}
@@ -27,6 +27,10 @@ func Info(msg string, fields ...zap.Field) {
 	Log.Info(msg, fields...)
 }
 
+func Warn(msg string, fields ...zap.Field) {
+	Log.Warn(msg, fields...)
+}
+
 func Error(msg string, fields ...zap.Field) {
 	Log.Error(msg, fields...)
 }
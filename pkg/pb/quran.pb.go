@@ -66,6 +66,8 @@ type Surah struct {
 	IndonesianName string                 `protobuf:"bytes,7,opt,name=indonesian_name,json=indonesianName,proto3" json:"indonesian_name,omitempty"` // FIELD BARU
 	RevelationType string                 `protobuf:"bytes,5,opt,name=revelation_type,json=revelationType,proto3" json:"revelation_type,omitempty"`
 	TotalAyahs     int32                  `protobuf:"varint,6,opt,name=total_ayahs,json=totalAyahs,proto3" json:"total_ayahs,omitempty"`
+	AvailableAyahs int32                  `protobuf:"varint,8,opt,name=available_ayahs,json=availableAyahs,proto3" json:"available_ayahs,omitempty"` // Jumlah ayat yang tersedia offline
+	IsComplete     bool                   `protobuf:"varint,9,opt,name=is_complete,json=isComplete,proto3" json:"is_complete,omitempty"`
 	unknownFields  protoimpl.UnknownFields
 	sizeCache      protoimpl.SizeCache
 }
@@ -149,12 +151,36 @@ func (x *Surah) GetTotalAyahs() int32 {
 	return 0
 }
 
+func (x *Surah) GetAvailableAyahs() int32 {
+	if x != nil {
+		return x.AvailableAyahs
+	}
+	return 0
+}
+
+func (x *Surah) GetIsComplete() bool {
+	if x != nil {
+		return x.IsComplete
+	}
+	return false
+}
+
 type Ayah struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Number        int32                  `protobuf:"varint,1,opt,name=number,proto3" json:"number,omitempty"`
 	TextArabic    string                 `protobuf:"bytes,2,opt,name=text_arabic,json=textArabic,proto3" json:"text_arabic,omitempty"`
 	TextLatin     string                 `protobuf:"bytes,3,opt,name=text_latin,json=textLatin,proto3" json:"text_latin,omitempty"`
 	Translation   string                 `protobuf:"bytes,4,opt,name=translation,proto3" json:"translation,omitempty"`
+	NumberInQuran int32                  `protobuf:"varint,5,opt,name=number_in_quran,json=numberInQuran,proto3" json:"number_in_quran,omitempty"` // Nomor ayat global (1-6236)
+	AudioUrl      string                 `protobuf:"bytes,6,opt,name=audio_url,json=audioUrl,proto3" json:"audio_url,omitempty"`
+	Juz           int32                  `protobuf:"varint,7,opt,name=juz,proto3" json:"juz,omitempty"`
+	Hizb          int32                  `protobuf:"varint,8,opt,name=hizb,proto3" json:"hizb,omitempty"`
+	Page          int32                  `protobuf:"varint,9,opt,name=page,proto3" json:"page,omitempty"`                                          // Halaman mushaf Madinah
+	Sajdah        *Sajdah                `protobuf:"bytes,10,opt,name=sajdah,proto3" json:"sajdah,omitempty"`                                      // Hanya terisi untuk ayat sajdah tilawah
+	Words         []*Word                `protobuf:"bytes,11,rep,name=words,proto3" json:"words,omitempty"`                                        // Hanya terisi jika include_words = true
+	Script        string                 `protobuf:"bytes,12,opt,name=script,proto3" json:"script,omitempty"`                                      // Edisi rasm text_arabic: uthmani | imlaei | indopak
+	Riwayah       string                 `protobuf:"bytes,13,opt,name=riwayah,proto3" json:"riwayah,omitempty"`                                    // Hanya terisi untuk riwayat selain hafs
+	HafsNumbers   []int32                `protobuf:"varint,14,rep,packed,name=hafs_numbers,json=hafsNumbers,proto3" json:"hafs_numbers,omitempty"` // Nomor ayat Hafs yang dicakup ayat riwayat ini
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -217,6 +243,282 @@ func (x *Ayah) GetTranslation() string {
 	return ""
 }
 
+func (x *Ayah) GetNumberInQuran() int32 {
+	if x != nil {
+		return x.NumberInQuran
+	}
+	return 0
+}
+
+func (x *Ayah) GetAudioUrl() string {
+	if x != nil {
+		return x.AudioUrl
+	}
+	return ""
+}
+
+func (x *Ayah) GetJuz() int32 {
+	if x != nil {
+		return x.Juz
+	}
+	return 0
+}
+
+func (x *Ayah) GetHizb() int32 {
+	if x != nil {
+		return x.Hizb
+	}
+	return 0
+}
+
+func (x *Ayah) GetPage() int32 {
+	if x != nil {
+		return x.Page
+	}
+	return 0
+}
+
+func (x *Ayah) GetSajdah() *Sajdah {
+	if x != nil {
+		return x.Sajdah
+	}
+	return nil
+}
+
+func (x *Ayah) GetWords() []*Word {
+	if x != nil {
+		return x.Words
+	}
+	return nil
+}
+
+func (x *Ayah) GetScript() string {
+	if x != nil {
+		return x.Script
+	}
+	return ""
+}
+
+func (x *Ayah) GetRiwayah() string {
+	if x != nil {
+		return x.Riwayah
+	}
+	return ""
+}
+
+func (x *Ayah) GetHafsNumbers() []int32 {
+	if x != nil {
+		return x.HafsNumbers
+	}
+	return nil
+}
+
+// Satu kata dalam ayat untuk mode kata per kata
+type Word struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	Position        int32                  `protobuf:"varint,1,opt,name=position,proto3" json:"position,omitempty"`
+	TextUthmani     string                 `protobuf:"bytes,2,opt,name=text_uthmani,json=textUthmani,proto3" json:"text_uthmani,omitempty"`
+	Transliteration string                 `protobuf:"bytes,3,opt,name=transliteration,proto3" json:"transliteration,omitempty"`
+	TranslationId   string                 `protobuf:"bytes,4,opt,name=translation_id,json=translationId,proto3" json:"translation_id,omitempty"` // Arti bahasa Indonesia
+	TranslationEn   string                 `protobuf:"bytes,5,opt,name=translation_en,json=translationEn,proto3" json:"translation_en,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *Word) Reset() {
+	*x = Word{}
+	mi := &file_quran_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Word) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Word) ProtoMessage() {}
+
+func (x *Word) ProtoReflect() protoreflect.Message {
+	mi := &file_quran_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Word.ProtoReflect.Descriptor instead.
+func (*Word) Descriptor() ([]byte, []int) {
+	return file_quran_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *Word) GetPosition() int32 {
+	if x != nil {
+		return x.Position
+	}
+	return 0
+}
+
+func (x *Word) GetTextUthmani() string {
+	if x != nil {
+		return x.TextUthmani
+	}
+	return ""
+}
+
+func (x *Word) GetTransliteration() string {
+	if x != nil {
+		return x.Transliteration
+	}
+	return ""
+}
+
+func (x *Word) GetTranslationId() string {
+	if x != nil {
+		return x.TranslationId
+	}
+	return ""
+}
+
+func (x *Word) GetTranslationEn() string {
+	if x != nil {
+		return x.TranslationEn
+	}
+	return ""
+}
+
+type Sajdah struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Number        int32                  `protobuf:"varint,1,opt,name=number,proto3" json:"number,omitempty"`
+	Type          string                 `protobuf:"bytes,2,opt,name=type,proto3" json:"type,omitempty"` // recommended | obligatory
+	Madhhab       *SajdahMadhhab         `protobuf:"bytes,3,opt,name=madhhab,proto3" json:"madhhab,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Sajdah) Reset() {
+	*x = Sajdah{}
+	mi := &file_quran_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Sajdah) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Sajdah) ProtoMessage() {}
+
+func (x *Sajdah) ProtoReflect() protoreflect.Message {
+	mi := &file_quran_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Sajdah.ProtoReflect.Descriptor instead.
+func (*Sajdah) Descriptor() ([]byte, []int) {
+	return file_quran_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *Sajdah) GetNumber() int32 {
+	if x != nil {
+		return x.Number
+	}
+	return 0
+}
+
+func (x *Sajdah) GetType() string {
+	if x != nil {
+		return x.Type
+	}
+	return ""
+}
+
+func (x *Sajdah) GetMadhhab() *SajdahMadhhab {
+	if x != nil {
+		return x.Madhhab
+	}
+	return nil
+}
+
+// Hukum per mazhab: obligatory | recommended | none
+type SajdahMadhhab struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Hanafi        string                 `protobuf:"bytes,1,opt,name=hanafi,proto3" json:"hanafi,omitempty"`
+	Maliki        string                 `protobuf:"bytes,2,opt,name=maliki,proto3" json:"maliki,omitempty"`
+	Shafii        string                 `protobuf:"bytes,3,opt,name=shafii,proto3" json:"shafii,omitempty"`
+	Hanbali       string                 `protobuf:"bytes,4,opt,name=hanbali,proto3" json:"hanbali,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SajdahMadhhab) Reset() {
+	*x = SajdahMadhhab{}
+	mi := &file_quran_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SajdahMadhhab) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SajdahMadhhab) ProtoMessage() {}
+
+func (x *SajdahMadhhab) ProtoReflect() protoreflect.Message {
+	mi := &file_quran_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SajdahMadhhab.ProtoReflect.Descriptor instead.
+func (*SajdahMadhhab) Descriptor() ([]byte, []int) {
+	return file_quran_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *SajdahMadhhab) GetHanafi() string {
+	if x != nil {
+		return x.Hanafi
+	}
+	return ""
+}
+
+func (x *SajdahMadhhab) GetMaliki() string {
+	if x != nil {
+		return x.Maliki
+	}
+	return ""
+}
+
+func (x *SajdahMadhhab) GetShafii() string {
+	if x != nil {
+		return x.Shafii
+	}
+	return ""
+}
+
+func (x *SajdahMadhhab) GetHanbali() string {
+	if x != nil {
+		return x.Hanbali
+	}
+	return ""
+}
+
 type SurahListResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Surahs        []*Surah               `protobuf:"bytes,1,rep,name=surahs,proto3" json:"surahs,omitempty"`
@@ -226,7 +528,7 @@ type SurahListResponse struct {
 
 func (x *SurahListResponse) Reset() {
 	*x = SurahListResponse{}
-	mi := &file_quran_proto_msgTypes[3]
+	mi := &file_quran_proto_msgTypes[6]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -238,7 +540,7 @@ func (x *SurahListResponse) String() string {
 func (*SurahListResponse) ProtoMessage() {}
 
 func (x *SurahListResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_quran_proto_msgTypes[3]
+	mi := &file_quran_proto_msgTypes[6]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -251,7 +553,7 @@ func (x *SurahListResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use SurahListResponse.ProtoReflect.Descriptor instead.
 func (*SurahListResponse) Descriptor() ([]byte, []int) {
-	return file_quran_proto_rawDescGZIP(), []int{3}
+	return file_quran_proto_rawDescGZIP(), []int{6}
 }
 
 func (x *SurahListResponse) GetSurahs() []*Surah {
@@ -264,13 +566,16 @@ func (x *SurahListResponse) GetSurahs() []*Surah {
 type SurahDetailRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Number        int32                  `protobuf:"varint,1,opt,name=number,proto3" json:"number,omitempty"`
+	IncludeWords  bool                   `protobuf:"varint,2,opt,name=include_words,json=includeWords,proto3" json:"include_words,omitempty"`
+	Script        string                 `protobuf:"bytes,3,opt,name=script,proto3" json:"script,omitempty"`   // Kosong berarti uthmani
+	Riwayah       string                 `protobuf:"bytes,4,opt,name=riwayah,proto3" json:"riwayah,omitempty"` // hafs | warsh | qalun, kosong berarti hafs
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
 func (x *SurahDetailRequest) Reset() {
 	*x = SurahDetailRequest{}
-	mi := &file_quran_proto_msgTypes[4]
+	mi := &file_quran_proto_msgTypes[7]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -282,7 +587,7 @@ func (x *SurahDetailRequest) String() string {
 func (*SurahDetailRequest) ProtoMessage() {}
 
 func (x *SurahDetailRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_quran_proto_msgTypes[4]
+	mi := &file_quran_proto_msgTypes[7]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -295,7 +600,7 @@ func (x *SurahDetailRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use SurahDetailRequest.ProtoReflect.Descriptor instead.
 func (*SurahDetailRequest) Descriptor() ([]byte, []int) {
-	return file_quran_proto_rawDescGZIP(), []int{4}
+	return file_quran_proto_rawDescGZIP(), []int{7}
 }
 
 func (x *SurahDetailRequest) GetNumber() int32 {
@@ -305,6 +610,27 @@ func (x *SurahDetailRequest) GetNumber() int32 {
 	return 0
 }
 
+func (x *SurahDetailRequest) GetIncludeWords() bool {
+	if x != nil {
+		return x.IncludeWords
+	}
+	return false
+}
+
+func (x *SurahDetailRequest) GetScript() string {
+	if x != nil {
+		return x.Script
+	}
+	return ""
+}
+
+func (x *SurahDetailRequest) GetRiwayah() string {
+	if x != nil {
+		return x.Riwayah
+	}
+	return ""
+}
+
 type SurahDetailResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Surah         *Surah                 `protobuf:"bytes,1,opt,name=surah,proto3" json:"surah,omitempty"`
@@ -315,7 +641,7 @@ type SurahDetailResponse struct {
 
 func (x *SurahDetailResponse) Reset() {
 	*x = SurahDetailResponse{}
-	mi := &file_quran_proto_msgTypes[5]
+	mi := &file_quran_proto_msgTypes[8]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -327,7 +653,7 @@ func (x *SurahDetailResponse) String() string {
 func (*SurahDetailResponse) ProtoMessage() {}
 
 func (x *SurahDetailResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_quran_proto_msgTypes[5]
+	mi := &file_quran_proto_msgTypes[8]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -340,7 +666,7 @@ func (x *SurahDetailResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use SurahDetailResponse.ProtoReflect.Descriptor instead.
 func (*SurahDetailResponse) Descriptor() ([]byte, []int) {
-	return file_quran_proto_rawDescGZIP(), []int{5}
+	return file_quran_proto_rawDescGZIP(), []int{8}
 }
 
 func (x *SurahDetailResponse) GetSurah() *Surah {
@@ -357,12 +683,56 @@ func (x *SurahDetailResponse) GetAyahs() []*Ayah {
 	return nil
 }
 
+type SurahListRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Riwayah       string                 `protobuf:"bytes,1,opt,name=riwayah,proto3" json:"riwayah,omitempty"` // hafs | warsh | qalun, kosong berarti hafs
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SurahListRequest) Reset() {
+	*x = SurahListRequest{}
+	mi := &file_quran_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SurahListRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SurahListRequest) ProtoMessage() {}
+
+func (x *SurahListRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_quran_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SurahListRequest.ProtoReflect.Descriptor instead.
+func (*SurahListRequest) Descriptor() ([]byte, []int) {
+	return file_quran_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *SurahListRequest) GetRiwayah() string {
+	if x != nil {
+		return x.Riwayah
+	}
+	return ""
+}
+
 var File_quran_proto protoreflect.FileDescriptor
 
 const file_quran_proto_rawDesc = "" +
 	"\n" +
 	"\vquran.proto\x12\x05quran\"\a\n" +
-	"\x05Empty\"\xe8\x01\n" +
+	"\x05Empty\"\xb2\x02\n" +
 	"\x05Surah\x12\x16\n" +
 	"\x06number\x18\x01 \x01(\x05R\x06number\x12\x12\n" +
 	"\x04name\x18\x02 \x01(\tR\x04name\x12\x1d\n" +
@@ -372,23 +742,57 @@ const file_quran_proto_rawDesc = "" +
 	"\x0findonesian_name\x18\a \x01(\tR\x0eindonesianName\x12'\n" +
 	"\x0frevelation_type\x18\x05 \x01(\tR\x0erevelationType\x12\x1f\n" +
 	"\vtotal_ayahs\x18\x06 \x01(\x05R\n" +
-	"totalAyahs\"\x80\x01\n" +
+	"totalAyahs\x12'\n" +
+	"\x0favailable_ayahs\x18\b \x01(\x05R\x0eavailableAyahs\x12\x1f\n" +
+	"\vis_complete\x18\t \x01(\bR\n" +
+	"isComplete\"\x9e\x03\n" +
 	"\x04Ayah\x12\x16\n" +
 	"\x06number\x18\x01 \x01(\x05R\x06number\x12\x1f\n" +
 	"\vtext_arabic\x18\x02 \x01(\tR\n" +
 	"textArabic\x12\x1d\n" +
 	"\n" +
 	"text_latin\x18\x03 \x01(\tR\ttextLatin\x12 \n" +
-	"\vtranslation\x18\x04 \x01(\tR\vtranslation\"9\n" +
+	"\vtranslation\x18\x04 \x01(\tR\vtranslation\x12&\n" +
+	"\x0fnumber_in_quran\x18\x05 \x01(\x05R\rnumberInQuran\x12\x1b\n" +
+	"\taudio_url\x18\x06 \x01(\tR\baudioUrl\x12\x10\n" +
+	"\x03juz\x18\a \x01(\x05R\x03juz\x12\x12\n" +
+	"\x04hizb\x18\b \x01(\x05R\x04hizb\x12\x12\n" +
+	"\x04page\x18\t \x01(\x05R\x04page\x12%\n" +
+	"\x06sajdah\x18\n" +
+	" \x01(\v2\r.quran.SajdahR\x06sajdah\x12!\n" +
+	"\x05words\x18\v \x03(\v2\v.quran.WordR\x05words\x12\x16\n" +
+	"\x06script\x18\f \x01(\tR\x06script\x12\x18\n" +
+	"\ariwayah\x18\r \x01(\tR\ariwayah\x12!\n" +
+	"\fhafs_numbers\x18\x0e \x03(\x05R\vhafsNumbers\"\xbd\x01\n" +
+	"\x04Word\x12\x1a\n" +
+	"\bposition\x18\x01 \x01(\x05R\bposition\x12!\n" +
+	"\ftext_uthmani\x18\x02 \x01(\tR\vtextUthmani\x12(\n" +
+	"\x0ftransliteration\x18\x03 \x01(\tR\x0ftransliteration\x12%\n" +
+	"\x0etranslation_id\x18\x04 \x01(\tR\rtranslationId\x12%\n" +
+	"\x0etranslation_en\x18\x05 \x01(\tR\rtranslationEn\"d\n" +
+	"\x06Sajdah\x12\x16\n" +
+	"\x06number\x18\x01 \x01(\x05R\x06number\x12\x12\n" +
+	"\x04type\x18\x02 \x01(\tR\x04type\x12.\n" +
+	"\amadhhab\x18\x03 \x01(\v2\x14.quran.SajdahMadhhabR\amadhhab\"q\n" +
+	"\rSajdahMadhhab\x12\x16\n" +
+	"\x06hanafi\x18\x01 \x01(\tR\x06hanafi\x12\x16\n" +
+	"\x06maliki\x18\x02 \x01(\tR\x06maliki\x12\x16\n" +
+	"\x06shafii\x18\x03 \x01(\tR\x06shafii\x12\x18\n" +
+	"\ahanbali\x18\x04 \x01(\tR\ahanbali\"9\n" +
 	"\x11SurahListResponse\x12$\n" +
-	"\x06surahs\x18\x01 \x03(\v2\f.quran.SurahR\x06surahs\",\n" +
+	"\x06surahs\x18\x01 \x03(\v2\f.quran.SurahR\x06surahs\"\x83\x01\n" +
 	"\x12SurahDetailRequest\x12\x16\n" +
-	"\x06number\x18\x01 \x01(\x05R\x06number\"\\\n" +
+	"\x06number\x18\x01 \x01(\x05R\x06number\x12#\n" +
+	"\rinclude_words\x18\x02 \x01(\bR\fincludeWords\x12\x16\n" +
+	"\x06script\x18\x03 \x01(\tR\x06script\x12\x18\n" +
+	"\ariwayah\x18\x04 \x01(\tR\ariwayah\"\\\n" +
 	"\x13SurahDetailResponse\x12\"\n" +
 	"\x05surah\x18\x01 \x01(\v2\f.quran.SurahR\x05surah\x12!\n" +
-	"\x05ayahs\x18\x02 \x03(\v2\v.quran.AyahR\x05ayahs2\x8f\x01\n" +
-	"\fQuranService\x126\n" +
-	"\fGetAllSurahs\x12\f.quran.Empty\x1a\x18.quran.SurahListResponse\x12G\n" +
+	"\x05ayahs\x18\x02 \x03(\v2\v.quran.AyahR\x05ayahs\",\n" +
+	"\x10SurahListRequest\x12\x18\n" +
+	"\ariwayah\x18\x01 \x01(\tR\ariwayah2\x9a\x01\n" +
+	"\fQuranService\x12A\n" +
+	"\fGetAllSurahs\x12\x17.quran.SurahListRequest\x1a\x18.quran.SurahListResponse\x12G\n" +
 	"\x0eGetSurahDetail\x12\x19.quran.SurahDetailRequest\x1a\x1a.quran.SurahDetailResponseB\x17Z\x15khalif-alquran/pkg/pbb\x06proto3"
 
 var (
@@ -403,28 +807,35 @@ func file_quran_proto_rawDescGZIP() []byte {
 	return file_quran_proto_rawDescData
 }
 
-var file_quran_proto_msgTypes = make([]protoimpl.MessageInfo, 6)
+var file_quran_proto_msgTypes = make([]protoimpl.MessageInfo, 10)
 var file_quran_proto_goTypes = []any{
 	(*Empty)(nil),               // 0: quran.Empty
 	(*Surah)(nil),               // 1: quran.Surah
 	(*Ayah)(nil),                // 2: quran.Ayah
-	(*SurahListResponse)(nil),   // 3: quran.SurahListResponse
-	(*SurahDetailRequest)(nil),  // 4: quran.SurahDetailRequest
-	(*SurahDetailResponse)(nil), // 5: quran.SurahDetailResponse
+	(*Word)(nil),                // 3: quran.Word
+	(*Sajdah)(nil),              // 4: quran.Sajdah
+	(*SajdahMadhhab)(nil),       // 5: quran.SajdahMadhhab
+	(*SurahListResponse)(nil),   // 6: quran.SurahListResponse
+	(*SurahDetailRequest)(nil),  // 7: quran.SurahDetailRequest
+	(*SurahDetailResponse)(nil), // 8: quran.SurahDetailResponse
+	(*SurahListRequest)(nil),    // 9: quran.SurahListRequest
 }
 var file_quran_proto_depIdxs = []int32{
-	1, // 0: quran.SurahListResponse.surahs:type_name -> quran.Surah
-	1, // 1: quran.SurahDetailResponse.surah:type_name -> quran.Surah
-	2, // 2: quran.SurahDetailResponse.ayahs:type_name -> quran.Ayah
-	0, // 3: quran.QuranService.GetAllSurahs:input_type -> quran.Empty
-	4, // 4: quran.QuranService.GetSurahDetail:input_type -> quran.SurahDetailRequest
-	3, // 5: quran.QuranService.GetAllSurahs:output_type -> quran.SurahListResponse
-	5, // 6: quran.QuranService.GetSurahDetail:output_type -> quran.SurahDetailResponse
-	5, // [5:7] is the sub-list for method output_type
-	3, // [3:5] is the sub-list for method input_type
-	3, // [3:3] is the sub-list for extension type_name
-	3, // [3:3] is the sub-list for extension extendee
-	0, // [0:3] is the sub-list for field type_name
+	4, // 0: quran.Ayah.sajdah:type_name -> quran.Sajdah
+	3, // 1: quran.Ayah.words:type_name -> quran.Word
+	5, // 2: quran.Sajdah.madhhab:type_name -> quran.SajdahMadhhab
+	1, // 3: quran.SurahListResponse.surahs:type_name -> quran.Surah
+	1, // 4: quran.SurahDetailResponse.surah:type_name -> quran.Surah
+	2, // 5: quran.SurahDetailResponse.ayahs:type_name -> quran.Ayah
+	9, // 6: quran.QuranService.GetAllSurahs:input_type -> quran.SurahListRequest
+	7, // 7: quran.QuranService.GetSurahDetail:input_type -> quran.SurahDetailRequest
+	6, // 8: quran.QuranService.GetAllSurahs:output_type -> quran.SurahListResponse
+	8, // 9: quran.QuranService.GetSurahDetail:output_type -> quran.SurahDetailResponse
+	8, // [8:10] is the sub-list for method output_type
+	6, // [6:8] is the sub-list for method input_type
+	6, // [6:6] is the sub-list for extension type_name
+	6, // [6:6] is the sub-list for extension extendee
+	0, // [0:6] is the sub-list for field type_name
 }
 
 func init() { file_quran_proto_init() }
@@ -438,7 +849,7 @@ func file_quran_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_quran_proto_rawDesc), len(file_quran_proto_rawDesc)),
 			NumEnums:      0,
-			NumMessages:   6,
+			NumMessages:   10,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
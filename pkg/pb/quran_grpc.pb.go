@@ -27,7 +27,7 @@ const (
 //
 // For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
 type QuranServiceClient interface {
-	GetAllSurahs(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*SurahListResponse, error)
+	GetAllSurahs(ctx context.Context, in *SurahListRequest, opts ...grpc.CallOption) (*SurahListResponse, error)
 	GetSurahDetail(ctx context.Context, in *SurahDetailRequest, opts ...grpc.CallOption) (*SurahDetailResponse, error)
 }
 
@@ -39,7 +39,7 @@ func NewQuranServiceClient(cc grpc.ClientConnInterface) QuranServiceClient {
 	return &quranServiceClient{cc}
 }
 
-func (c *quranServiceClient) GetAllSurahs(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*SurahListResponse, error) {
+func (c *quranServiceClient) GetAllSurahs(ctx context.Context, in *SurahListRequest, opts ...grpc.CallOption) (*SurahListResponse, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
 	out := new(SurahListResponse)
 	err := c.cc.Invoke(ctx, QuranService_GetAllSurahs_FullMethodName, in, out, cOpts...)
@@ -63,7 +63,7 @@ func (c *quranServiceClient) GetSurahDetail(ctx context.Context, in *SurahDetail
 // All implementations must embed UnimplementedQuranServiceServer
 // for forward compatibility.
 type QuranServiceServer interface {
-	GetAllSurahs(context.Context, *Empty) (*SurahListResponse, error)
+	GetAllSurahs(context.Context, *SurahListRequest) (*SurahListResponse, error)
 	GetSurahDetail(context.Context, *SurahDetailRequest) (*SurahDetailResponse, error)
 	mustEmbedUnimplementedQuranServiceServer()
 }
@@ -75,7 +75,7 @@ type QuranServiceServer interface {
 // pointer dereference when methods are called.
 type UnimplementedQuranServiceServer struct{}
 
-func (UnimplementedQuranServiceServer) GetAllSurahs(context.Context, *Empty) (*SurahListResponse, error) {
+func (UnimplementedQuranServiceServer) GetAllSurahs(context.Context, *SurahListRequest) (*SurahListResponse, error) {
 	return nil, status.Error(codes.Unimplemented, "method GetAllSurahs not implemented")
 }
 func (UnimplementedQuranServiceServer) GetSurahDetail(context.Context, *SurahDetailRequest) (*SurahDetailResponse, error) {
@@ -103,7 +103,7 @@ func RegisterQuranServiceServer(s grpc.ServiceRegistrar, srv QuranServiceServer)
 }
 
 func _QuranService_GetAllSurahs_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(Empty)
+	in := new(SurahListRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
@@ -115,7 +115,7 @@ func _QuranService_GetAllSurahs_Handler(srv interface{}, ctx context.Context, de
 		FullMethod: QuranService_GetAllSurahs_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(QuranServiceServer).GetAllSurahs(ctx, req.(*Empty))
+		return srv.(QuranServiceServer).GetAllSurahs(ctx, req.(*SurahListRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
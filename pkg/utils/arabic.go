@@ -0,0 +1,126 @@
+package utils
+
+import (
+	"sort"
+	"strings"
+	"unicode"
+
+)
+
+// Huruf yang disatukan saat normalisasi: variasi alif/hamzah, alif kecil (U+0670) yang ditulis
+// sebagai alif biasa di ejaan imlaei, ta marbuthah, alif maqshurah, serta ya dan kaf Persia yang
+// dipakai mushaf Indo-Pak
+var arabicFold = map[rune]rune{
+	'أ': 'ا', 'إ': 'ا', 'آ': 'ا', 'ٱ': 'ا', 'ٲ': 'ا', 'ٳ': 'ا', '\u0670': 'ا',
+	'ؤ': 'و', 'ئ': 'ي',
+	'ة': 'ه',
+	'ى': 'ي', 'ی': 'ي', 'ې': 'ي',
+	'ک': 'ك',
+}
+
+// isArabicMark bernilai true untuk karakter yang dibuang saat normalisasi: harakat dan tanwin
+// (U+064B-U+065F), tatwil, serta tanda khas rasm Uthmani seperti tanda waqaf, sukun bulat, dan
+// wau/ya kecil (U+06D6-U+06ED). Alif kecil tidak dibuang karena sudah diubah lewat arabicFold.
+func isArabicMark(r rune) bool {
+	return (r >= 0x064B && r <= 0x065F) || r == 0x0640 || (r >= 0x06D6 && r <= 0x06ED)
+}
+
+// NormalizeArabic menyatukan variasi huruf, lalu membuang harakat dan tanda rasm, sehingga
+// "ٱلْكِتَٰبِ" dan "الكتاب" menjadi sama. Dipakai saat mengindeks maupun saat menerima query;
+// text_normalized yang sudah tersimpan ditulis ulang oleh normalizeAyahs saat seeding berikutnya.
+func NormalizeArabic(s string) string {
+	normalized, _ := NormalizeArabicIndex(s)
+	return normalized
+}
+
+// NormalizeArabicIndex sama dengan NormalizeArabic, ditambah index rune asal (di s) untuk setiap
+// rune hasil normalisasi, agar posisi kecocokan bisa dipetakan kembali ke teks yang berharakat
+func NormalizeArabicIndex(s string) (string, []int) {
+	var b strings.Builder
+	index := make([]int, 0, len(s))
+
+	i := 0
+	for _, r := range s {
+		if folded, ok := arabicFold[r]; ok {
+			r = folded
+		}
+		if !isArabicMark(r) {
+			b.WriteRune(r)
+			index = append(index, i)
+		}
+		i++
+	}
+	return b.String(), index
+}
+
+// HasArabic bernilai true jika s mengandung setidaknya satu huruf Arab
+func HasArabic(s string) bool {
+	for _, r := range s {
+		if unicode.Is(unicode.Arabic, r) && unicode.IsLetter(r) {
+			return true
+		}
+	}
+	return false
+}
+
+// FindArabic mencari setiap kata query (setelah dinormalisasi) di text tanpa memedulikan harakat.
+// Hasilnya rentang rune [start, end) pada text asli yang sudah diurutkan dan digabung jika bertumpuk;
+// end mencakup harakat yang menempel pada huruf terakhir.
+func FindArabic(text, query string) [][2]int {
+	normalized, index := NormalizeArabicIndex(text)
+	haystack := []rune(normalized)
+	total := len([]rune(text))
+
+	var ranges [][2]int
+	for _, word := range strings.FieldsFunc(NormalizeArabic(query), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	}) {
+		needle := []rune(word)
+		for from := 0; from+len(needle) <= len(haystack); {
+			pos := runeIndex(haystack[from:], needle)
+			if pos < 0 {
+				break
+			}
+			start, end := from+pos, from+pos+len(needle)
+			origEnd := total
+			if end < len(index) {
+				origEnd = index[end]
+			}
+			ranges = append(ranges, [2]int{index[start], origEnd})
+			from = end
+		}
+	}
+	if len(ranges) == 0 {
+		return nil
+	}
+
+	sort.Slice(ranges, func(i, j int) bool { return ranges[i][0] < ranges[j][0] })
+	merged := [][2]int{ranges[0]}
+	for _, r := range ranges[1:] {
+		last := &merged[len(merged)-1]
+		if r[0] <= last[1] {
+			if r[1] > last[1] {
+				last[1] = r[1]
+			}
+			continue
+		}
+		merged = append(merged, r)
+	}
+	return merged
+}
+
+func runeIndex(haystack, needle []rune) int {
+	for i := 0; i+len(needle) <= len(haystack); i++ {
+		match := true
+		for j := range needle {
+			if haystack[i+j] != needle[j] {
+				match = false
+				break
+			}
+		}
+		if match {
+			return i
+		}
+	}
+	return -1
+}
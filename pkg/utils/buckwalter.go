@@ -0,0 +1,58 @@
+package utils
+
+import "strings"
+
+// Tabel Buckwalter yang diperluas (dipakai Quranic Arabic Corpus), termasuk tanda waqaf
+// dan huruf kecil khas rasm Uthmani
+var buckwalterToArabic = map[rune]rune{
+	'\'': 'ء', '>': 'أ', '&': 'ؤ', '<': 'إ', '}': 'ئ',
+	'A': 'ا', 'b': 'ب', 'p': 'ة', 't': 'ت', 'v': 'ث',
+	'j': 'ج', 'H': 'ح', 'x': 'خ', 'd': 'د', '*': 'ذ',
+	'r': 'ر', 'z': 'ز', 's': 'س', '$': 'ش', 'S': 'ص',
+	'D': 'ض', 'T': 'ط', 'Z': 'ظ', 'E': 'ع', 'g': 'غ',
+	'_': 'ـ', 'f': 'ف', 'q': 'ق', 'k': 'ك', 'l': 'ل',
+	'm': 'م', 'n': 'ن', 'h': 'ه', 'w': 'و', 'Y': 'ى',
+	'y': 'ي', 'F': 'ً', 'N': 'ٌ', 'K': 'ٍ', 'a': 'َ',
+	'u': 'ُ', 'i': 'ِ', '~': 'ّ', 'o': 'ْ', '^': 'ٓ',
+	'#': 'ٔ', '`': 'ٰ', '{': 'ٱ', ':': 'ۜ', '@': '۟',
+	'"': '۠', '[': 'ۢ', ';': 'ۣ', ',': 'ۥ', '.': 'ۦ',
+	'!': 'ۨ', '-': '۪', '+': '۫', '%': '۬', ']': 'ۭ',
+}
+
+var arabicToBuckwalter = func() map[rune]rune {
+	m := make(map[rune]rune, len(buckwalterToArabic))
+	for bw, ar := range buckwalterToArabic {
+		m[ar] = bw
+	}
+	return m
+}()
+
+// BuckwalterToArabic mengubah transliterasi Buckwalter ke huruf Arab. Karakter di luar tabel dibiarkan.
+func BuckwalterToArabic(s string) string {
+	return strings.Map(func(r rune) rune {
+		if ar, ok := buckwalterToArabic[r]; ok {
+			return ar
+		}
+		return r
+	}, s)
+}
+
+// ArabicToBuckwalter adalah kebalikan BuckwalterToArabic
+func ArabicToBuckwalter(s string) string {
+	return strings.Map(func(r rune) rune {
+		if bw, ok := arabicToBuckwalter[r]; ok {
+			return bw
+		}
+		return r
+	}, s)
+}
+
+// IsBuckwalter bernilai true jika s hanya berisi karakter ASCII (bukan huruf Arab)
+func IsBuckwalter(s string) bool {
+	for _, r := range s {
+		if r > 0x7F {
+			return false
+		}
+	}
+	return s != ""
+}
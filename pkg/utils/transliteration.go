@@ -0,0 +1,92 @@
+package utils
+
+import (
+	"strings"
+	"unicode"
+
+)
+
+// Huruf Latin bertanda dari transliterasi (Kemenag, ALA-LC) dan padanan polosnya
+var latinFold = map[rune]rune{
+	'ā': 'a', 'á': 'a', 'â': 'a', 'à': 'a',
+	'ī': 'i', 'í': 'i', 'î': 'i',
+	'ū': 'u', 'ú': 'u', 'û': 'u',
+	'ḥ': 'h', 'ḫ': 'h', 'ẖ': 'h',
+	'ṣ': 's', 'ṡ': 's', 'š': 's', 'ś': 's',
+	'ḍ': 'd', 'ḏ': 'd',
+	'ṭ': 't', 'ṯ': 't',
+	'ẓ': 'z', 'ż': 'z', 'ž': 'z',
+	'ġ': 'g', 'ǧ': 'j',
+}
+
+// Ejaan dua huruf yang mewakili satu huruf Arab. Urutan penting: "ts" diganti sebelum "sy"/"sh".
+var latinDigraphs = strings.NewReplacer(
+	"kh", "k", "gh", "g",
+	"ts", "s", "th", "t",
+	"sy", "s", "sh", "s",
+	"dz", "z", "dh", "d", "zh", "z",
+)
+
+// Kata pertama yang dibuang: kata sandang Arab beserta bentuk asimilasinya, "the", serta awalan
+// seperti "surah"/"surat"/"QS" yang sering ikut diketik pengguna
+var nameArticles = map[string]bool{
+	"al": true, "an": true, "ar": true, "as": true, "asy": true, "ash": true, "at": true, "ats": true,
+	"ath": true, "az": true, "ad": true, "adz": true, "adh": true, "el": true, "the": true,
+}
+
+var namePrefixes = map[string]bool{
+	"surah": true, "surat": true, "suroh": true, "qs": true,
+}
+
+// SurahNameKey mengubah nama surah menjadi kunci pencarian yang tahan variasi ejaan, sehingga
+// "Al-Baqarah", "al baqoroh" dan "baqara" sama-sama menjadi "bakara", dan "Ya-Sin" sama dengan "yaasin".
+// Langkahnya: huruf kecil, tanda diakritik dan apostrof dibuang, kata sandang di depan dibuang,
+// ejaan dua huruf disederhanakan, o→a, e→i, y→i, q→k, huruf berulang disatukan, dan h di akhir
+// setelah vokal dibuang. Nama Arab dinormalisasi dengan NormalizeArabic dan spasinya dibuang.
+func SurahNameKey(s string) string {
+	if HasArabic(s) {
+		words := strings.Fields(NormalizeArabic(s))
+		if len(words) > 1 && words[0] == "سوره" {
+			words = words[1:]
+		}
+		return strings.Join(words, "")
+	}
+
+	var b strings.Builder
+	for _, r := range strings.ToLower(s) {
+		if folded, ok := latinFold[r]; ok {
+			r = folded
+		}
+		switch {
+		case r == '\'' || r == '‘' || r == '’' || r == '`' || r == 'ʿ' || r == 'ʾ':
+			continue
+		case unicode.IsLetter(r) || unicode.IsDigit(r):
+			b.WriteRune(r)
+		default:
+			b.WriteRune(' ')
+		}
+	}
+
+	words := strings.Fields(b.String())
+	for len(words) > 1 && namePrefixes[words[0]] {
+		words = words[1:]
+	}
+	if len(words) > 1 && nameArticles[words[0]] {
+		words = words[1:]
+	}
+
+	key := latinDigraphs.Replace(strings.Join(words, ""))
+	key = strings.NewReplacer("o", "a", "e", "i", "y", "i", "q", "k").Replace(key)
+
+	runes := make([]rune, 0, len(key))
+	for _, r := range key {
+		if n := len(runes); n > 0 && runes[n-1] == r {
+			continue
+		}
+		runes = append(runes, r)
+	}
+	if n := len(runes); n > 2 && runes[n-1] == 'h' && strings.ContainsRune("aiu", runes[n-2]) {
+		runes = runes[:n-1]
+	}
+	return string(runes)
+}